@@ -0,0 +1,79 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+const (
+	AccessoryTypeCosign   = "signature.cosign"
+	AccessoryTypeNotation = "signature.notation"
+	AccessoryTypeSbom     = "harbor.sbom"
+	AccessoryTypeSubject  = "subject.accessory"
+)
+
+// Accessory is an artifact attached to another one, its subject, such as a
+// signature, an SBOM or an attestation.
+type Accessory struct {
+	Id                    int    `json:"id"`
+	ArtifactId            int    `json:"artifact_id"`
+	SubjectArtifactId     int    `json:"subject_artifact_id"`
+	SubjectArtifactDigest string `json:"subject_artifact_digest"`
+	SubjectArtifactRepo   string `json:"subject_artifact_repo"`
+	Size                  int    `json:"size"`
+	Digest                string `json:"digest"`
+	Type                  string `json:"type"`
+	Icon                  string `json:"icon"`
+	CreationTime          string `json:"creation_time"`
+}
+
+func (a Accessory) IsSignature() bool {
+	return strings.HasPrefix(a.Type, "signature.")
+}
+
+func (a Accessory) IsSbom() bool {
+	return a.Type == AccessoryTypeSbom
+}
+
+// IsSigned reports whether the artifact has a signature accessory or a
+// tag signed through content trust.
+func (a ArtifactsResult) IsSigned() bool {
+	for _, accessory := range a.Accessories {
+		if accessory.IsSignature() {
+			return true
+		}
+	}
+
+	for _, t := range a.Tags {
+		if t.Signed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a ArtifactsResult) HasSbom() bool {
+	for _, accessory := range a.Accessories {
+		if accessory.IsSbom() {
+			return true
+		}
+	}
+	return false
+}
+
+func (h harborApiClient) FetchAccessories(ctx context.Context, project string, repository string, reference string) (*[]Accessory, error) {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s/accessories", h.baseUrl, project, repository, reference)
+	slog.Debug(fmt.Sprintf("Fetching accessories. URL: %s", url))
+
+	accessoriesResp, err := fetchAllPages[Accessory](ctx, h, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Accessories fetched", "data", fmt.Sprintf("%+v", accessoriesResp))
+
+	return &accessoriesResp, nil
+}
@@ -0,0 +1,46 @@
+package harbor
+
+import "testing"
+
+func TestArtifactAccessories(t *testing.T) {
+	tests := []struct {
+		name       string
+		artifact   ArtifactsResult
+		wantSigned bool
+		wantSbom   bool
+	}{
+		{name: "none", artifact: ArtifactsResult{}},
+		{
+			name:       "cosign signature",
+			artifact:   ArtifactsResult{Accessories: []Accessory{{Type: AccessoryTypeCosign}}},
+			wantSigned: true,
+		},
+		{
+			name:       "notation signature and sbom",
+			artifact:   ArtifactsResult{Accessories: []Accessory{{Type: AccessoryTypeNotation}, {Type: AccessoryTypeSbom}}},
+			wantSigned: true,
+			wantSbom:   true,
+		},
+		{
+			name:     "attestation only",
+			artifact: ArtifactsResult{Accessories: []Accessory{{Type: AccessoryTypeSubject}, {Type: AccessoryTypeSbom}}},
+			wantSbom: true,
+		},
+		{
+			name:       "content trust",
+			artifact:   ArtifactsResult{Tags: []Tag{{Name: "v1", Signed: true}}},
+			wantSigned: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.artifact.IsSigned(); got != tt.wantSigned {
+				t.Errorf("IsSigned() = %t, want %t", got, tt.wantSigned)
+			}
+			if got := tt.artifact.HasSbom(); got != tt.wantSbom {
+				t.Errorf("HasSbom() = %t, want %t", got, tt.wantSbom)
+			}
+		})
+	}
+}
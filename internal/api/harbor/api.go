@@ -1,11 +1,17 @@
 package harbor
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 )
 
 type harborApiClient struct {
@@ -34,3 +40,96 @@ func NewHarborApiClient(client *http.Client) (harborApiClient, error) {
 		credentials,
 	}, nil
 }
+
+// newRequest creates a request to url bound to ctx with the headers every
+// harbor endpoint expects. When body is not nil it is sent json encoded.
+func (h harborApiClient) newRequest(ctx context.Context, method string, url string, body any) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", h.credentials))
+	req.Header.Set("User-Agent", "harborw/1.0")
+
+	return req, nil
+}
+
+// additionUrl resolves the url of an addition link, which harbor usually
+// returns relative to its base url.
+func (h harborApiClient) additionUrl(link AdditionLink) string {
+	if link.Absolute {
+		return link.Href
+	}
+	return h.baseUrl + link.Href
+}
+
+// registryName undoes the escaping the harbor api requires on repository
+// names, the registry api expects them verbatim.
+func registryName(repository string) string {
+	for strings.Contains(repository, "%") {
+		unescaped, err := url.PathUnescape(repository)
+		if err != nil || unescaped == repository {
+			break
+		}
+		repository = unescaped
+	}
+	return repository
+}
+
+// get fetches url and decodes its json response into out.
+func (h harborApiClient) get(ctx context.Context, url string, out any) error {
+	req, err := h.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.do(req, out)
+	return err
+}
+
+// do sends req and decodes the json response into out, which may be nil when
+// the body is not needed. A *[]byte out receives the raw body instead, for
+// endpoints answering plain text. Unsuccessful responses are returned as
+// *Error.
+func (h harborApiClient) do(req *http.Request, out any) (*http.Response, error) {
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
+			slog.Debug("Could not decode harbor error payload", "err", err)
+		}
+		return resp, apiErr
+	}
+
+	if raw, ok := out.(*[]byte); ok {
+		if *raw, err = io.ReadAll(resp.Body); err != nil {
+			return resp, fmt.Errorf("failed to read response: %w", err)
+		}
+		return resp, nil
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
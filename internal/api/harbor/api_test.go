@@ -0,0 +1,49 @@
+package harbor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegistryName(t *testing.T) {
+	tests := []struct {
+		repository string
+		want       string
+	}{
+		{repository: "nginx", want: "nginx"},
+		{repository: "team%2Fapp", want: "team/app"},
+		{repository: "team%252Fapp", want: "team/app"},
+		{repository: "a%252Fb%252Fc", want: "a/b/c"},
+		{repository: "broken%zz", want: "broken%zz"},
+	}
+
+	for _, tt := range tests {
+		if got := registryName(tt.repository); got != tt.want {
+			t.Errorf("registryName(%q) = %q, want %q", tt.repository, got, tt.want)
+		}
+	}
+}
+
+func TestRequestCancelled(t *testing.T) {
+	requested := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requested)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	h := harborApiClient{client: server.Client(), baseUrl: server.URL}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requested
+		cancel()
+	}()
+
+	_, err := h.FetchProjects(ctx, QueryOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("FetchProjects() error = %v, want context.Canceled", err)
+	}
+}
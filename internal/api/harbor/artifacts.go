@@ -1,55 +1,34 @@
 package harbor
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"net/http"
+	"sync"
 )
 
-type BuildHistory struct {
+type AdditionLink struct {
 	Absolute bool   `json:"absolute"`
 	Href     string `json:"href"`
 }
 
 type AdditionLinks struct {
-	BuildHistory BuildHistory `json:"build_history"`
-}
-
-type N80Tcp struct {
-}
-
-type N8080Tcp struct {
-}
-
-type N8443Tcp struct {
-}
-
-type N9001Tcp struct {
-}
-
-type Exposedports struct {
-	N80Tcp   N80Tcp   `json:"80/tcp"`
-	N8080Tcp N8080Tcp `json:"8080/tcp"`
-	N8443Tcp N8443Tcp `json:"8443/tcp"`
-	N9001Tcp N9001Tcp `json:"9001/tcp"`
-}
-
-type Labels struct {
-	DevopsService string `json:"devops-service"`
-	Maintainer    string `json:"maintainer"`
-	Version       string `json:"version"`
+	BuildHistory    AdditionLink `json:"build_history"`
+	Vulnerabilities AdditionLink `json:"vulnerabilities"`
 }
 
 type Config struct {
-	Cmd          []any        `json:"Cmd"`
-	Entrypoint   []any        `json:"Entrypoint"`
-	Env          []any        `json:"Env"`
-	Exposedports Exposedports `json:"ExposedPorts"`
-	Labels       Labels       `json:"Labels"`
-	Stopsignal   string       `json:"StopSignal"`
-	User         string       `json:"User"`
-	Workingdir   string       `json:"WorkingDir"`
+	Cmd        []string `json:"Cmd"`
+	Entrypoint []string `json:"Entrypoint"`
+	Env        []string `json:"Env"`
+	// Exposedports is keyed by port and protocol, e.g. "8080/tcp"
+	Exposedports map[string]struct{} `json:"ExposedPorts"`
+	Labels       map[string]string   `json:"Labels"`
+	Volumes      map[string]struct{} `json:"Volumes"`
+	Stopsignal   string              `json:"StopSignal"`
+	User         string              `json:"User"`
+	Workingdir   string              `json:"WorkingDir"`
 }
 
 type ExtraAttrs struct {
@@ -71,87 +50,141 @@ type Tag struct {
 	Signed       bool   `json:"signed"`
 }
 
+type Platform struct {
+	Os           string   `json:"os"`
+	Architecture string   `json:"architecture"`
+	Variant      string   `json:"variant"`
+	OsVersion    string   `json:"os.version"`
+	OsFeatures   []string `json:"os.features"`
+}
+
+func (p Platform) String() string {
+	platform := fmt.Sprintf("%s/%s", p.Os, p.Architecture)
+	if p.Variant != "" {
+		platform = fmt.Sprintf("%s/%s", platform, p.Variant)
+	}
+	return platform
+}
+
+// Reference links an image index to one of the manifests it lists.
+type Reference struct {
+	ParentId    int               `json:"parent_id"`
+	ChildId     int               `json:"child_id"`
+	ChildDigest string            `json:"child_digest"`
+	Platform    *Platform         `json:"platform"`
+	Annotations map[string]string `json:"annotations"`
+	Urls        []string          `json:"urls"`
+}
+
 type ArtifactsResult struct {
-	AdditionLinks     AdditionLinks `json:"addition_links"`
-	Digest            string        `json:"digest"`
-	ExtraAttrs        ExtraAttrs    `json:"extra_attrs"`
-	Icon              string        `json:"icon"`
-	Id                int           `json:"id"`
-	Labels            any           `json:"labels"`
-	ManifestMediaType string        `json:"manifest_media_type"`
-	MediaType         string        `json:"media_type"`
-	ProjectId         int           `json:"project_id"`
-	PullTime          string        `json:"pull_time"`
-	PushTime          string        `json:"push_time"`
-	References        any           `json:"references"`
-	RepositoryId      int           `json:"repository_id"`
-	Size              int           `json:"size"`
-	Tags              []Tag         `json:"tags"`
-	Type              string        `json:"type"`
+	Accessories       []Accessory             `json:"accessories"`
+	AdditionLinks     AdditionLinks           `json:"addition_links"`
+	Digest            string                  `json:"digest"`
+	ExtraAttrs        ExtraAttrs              `json:"extra_attrs"`
+	Icon              string                  `json:"icon"`
+	Id                int                     `json:"id"`
+	Labels            []Label                 `json:"labels"`
+	ManifestMediaType string                  `json:"manifest_media_type"`
+	MediaType         string                  `json:"media_type"`
+	ProjectId         int                     `json:"project_id"`
+	PullTime          string                  `json:"pull_time"`
+	PushTime          string                  `json:"push_time"`
+	References        []Reference             `json:"references"`
+	RepositoryId      int                     `json:"repository_id"`
+	ScanOverview      map[string]ScanOverview `json:"scan_overview"`
+	Size              int                     `json:"size"`
+	Tags              []Tag                   `json:"tags"`
+	Type              string                  `json:"type"`
 }
 
-func (h harborApiClient) FetchArtifacts(project string, repository string) (*[]ArtifactsResult, error) {
+func (h harborApiClient) FetchArtifacts(ctx context.Context, project string, repository string, opts QueryOptions) (*[]ArtifactsResult, error) {
 	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts", h.baseUrl, project, repository)
 	slog.Debug(fmt.Sprintf("Fetching artifacts. URL: %s", url))
-	req, err := http.NewRequest("GET", url, nil)
+
+	query, err := opts.Values()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	query.Set("with_scan_overview", "true")
+	query.Set("with_label", "true")
+	query.Set("with_accessory", "true")
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", h.credentials))
-	req.Header.Set("User-Agent", "harborw/1.0")
+	artifactsResp, err := fetchAllPages[ArtifactsResult](ctx, h, url, query)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Artifacts fetched", "data", fmt.Sprintf("%+v", artifactsResp))
 
-	q := req.URL.Query()
-	q.Add("page", "1")
-	q.Add("page_size", "100")
-	req.URL.RawQuery = q.Encode()
+	return &artifactsResp, nil
+}
 
-	resp, err := h.client.Do(req)
+func (h harborApiClient) FetchArtifact(ctx context.Context, project string, repository string, reference string) (*ArtifactsResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s?with_scan_overview=true&with_label=true&with_accessory=true", h.baseUrl, project, repository, reference)
+	slog.Debug(fmt.Sprintf("Fetching artifact. URL: %s", url))
+	req, err := h.newRequest(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+	var artifactResp ArtifactsResult
+	if _, err := h.do(req, &artifactResp); err != nil {
+		return nil, err
 	}
 
-	var artifactsResp []ArtifactsResult
-	if err := json.NewDecoder(resp.Body).Decode(&artifactsResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+	slog.Debug("Artifact fetched", "data", fmt.Sprintf("%+v", artifactResp))
 
-	slog.Debug("Artifacts fetched", "data", fmt.Sprintf("%+v", artifactsResp))
+	return &artifactResp, nil
+}
 
-	return &artifactsResp, nil
+// IsIndex reports whether the artifact is an image index, also known as a
+// manifest list, referencing one manifest per platform.
+func (a ArtifactsResult) IsIndex() bool {
+	return len(a.References) > 0
 }
 
-func (h harborApiClient) DeleteArtifact(project string, repository string, artifactHashOrTag string) error {
-	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s", h.baseUrl, project, repository, artifactHashOrTag)
-	slog.Debug(fmt.Sprintf("Deleting artifact. URL: %s", url))
-	req, err := http.NewRequest("DELETE", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// FetchChildArtifacts returns the artifacts referenced by an image index,
+// in the order the index lists them. The children are fetched at once, the
+// transport of the client bounds the requests in flight.
+func (h harborApiClient) FetchChildArtifacts(ctx context.Context, project string, repository string, index ArtifactsResult) (*[]ArtifactsResult, error) {
+	slog.Debug(fmt.Sprintf("Fetching %d children of index %s", len(index.References), index.Digest))
+	children := make([]ArtifactsResult, len(index.References))
+	errs := make([]error, len(index.References))
+	var wg sync.WaitGroup
+	for i, r := range index.References {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			child, err := h.FetchArtifact(ctx, project, repository, r.ChildDigest)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to fetch child %s: %w", r.ChildDigest, err)
+				return
+			}
+			children[i] = *child
+		}()
 	}
+	wg.Wait()
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", h.credentials))
-	req.Header.Set("User-Agent", "harborw/1.0")
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
 
-	resp, err := h.client.Do(req)
+	return &children, nil
+}
+
+func (h harborApiClient) DeleteArtifact(ctx context.Context, project string, repository string, artifactHashOrTag string) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s", h.baseUrl, project, repository, artifactHashOrTag)
+	slog.Debug(fmt.Sprintf("Deleting artifact. URL: %s", url))
+	req, err := h.newRequest(ctx, "DELETE", url, nil)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("status code: %d", resp.StatusCode)
+	if _, err := h.do(req, nil); err != nil {
+		return err
 	}
 
-	slog.Debug(fmt.Sprintf("Artifact with hash: %s deleted", artifactHashOrTag[:10]))
+	slog.Debug(fmt.Sprintf("Artifact %s deleted", artifactHashOrTag))
 
 	return nil
 }
@@ -0,0 +1,63 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+)
+
+func TestFetchChildArtifacts(t *testing.T) {
+	index := ArtifactsResult{
+		Digest: "sha256:index",
+		References: []Reference{
+			{ChildDigest: "sha256:amd64"},
+			{ChildDigest: "sha256:arm64"},
+			{ChildDigest: "sha256:s390x"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		missing string
+		want    []string
+		wantErr bool
+	}{
+		{name: "every child in index order", want: []string{"sha256:amd64", "sha256:arm64", "sha256:s390x"}},
+		{name: "missing child", missing: "sha256:arm64", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				digest := path.Base(r.URL.Path)
+				if digest == tt.missing {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprintf(w, `{"digest": %q}`, digest)
+			}))
+			defer server.Close()
+
+			h := harborApiClient{client: server.Client(), baseUrl: server.URL}
+			got, err := h.FetchChildArtifacts(context.Background(), "library", "app", index)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchChildArtifacts() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(*got) != len(tt.want) {
+				t.Fatalf("FetchChildArtifacts() returned %d children, want %d", len(*got), len(tt.want))
+			}
+			for i, child := range *got {
+				if child.Digest != tt.want[i] {
+					t.Errorf("child %d = %s, want %s", i, child.Digest, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+type AuditLogResult struct {
+	Id           int    `json:"id"`
+	Username     string `json:"username"`
+	Resource     string `json:"resource"`
+	ResourceType string `json:"resource_type"`
+	Operation    string `json:"operation"`
+	OpTime       string `json:"op_time"`
+}
+
+// FetchAuditLogs returns one page of the audit logs of project, or of the
+// whole system when project is empty, which requires admin rights. Logs are
+// returned most recent first unless opts sorts them otherwise.
+func (h harborApiClient) FetchAuditLogs(ctx context.Context, project string, opts QueryOptions, page int, pageSize int) (*[]AuditLogResult, error) {
+	if len(opts.Sort) == 0 {
+		opts.Sort = []string{"-op_time"}
+	}
+	query, err := opts.Values()
+	if err != nil {
+		return nil, err
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+
+	url := fmt.Sprintf("%s/api/v2.0/audit-logs?%s", h.baseUrl, query.Encode())
+	if project != "" {
+		url = fmt.Sprintf("%s/api/v2.0/projects/%s/logs?%s", h.baseUrl, project, query.Encode())
+	}
+	slog.Debug(fmt.Sprintf("Fetching audit logs. URL: %s", url))
+
+	var logsResp []AuditLogResult
+	if err := h.get(ctx, url, &logsResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Audit logs fetched", "data", fmt.Sprintf("%+v", logsResp))
+
+	return &logsResp, nil
+}
@@ -0,0 +1,35 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type BuildHistoryItem struct {
+	Created    string `json:"created"`
+	CreatedBy  string `json:"created_by"`
+	EmptyLayer bool   `json:"empty_layer"`
+	Comment    string `json:"comment"`
+	Author     string `json:"author"`
+}
+
+// FetchBuildHistory follows the build_history addition link of an image
+// artifact and returns the history of its layers, oldest first.
+func (h harborApiClient) FetchBuildHistory(ctx context.Context, link AdditionLink) (*[]BuildHistoryItem, error) {
+	url := h.additionUrl(link)
+	slog.Debug(fmt.Sprintf("Fetching build history. URL: %s", url))
+	req, err := h.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var historyResp []BuildHistoryItem
+	if _, err := h.do(req, &historyResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Build history fetched", "data", fmt.Sprintf("%+v", historyResp))
+
+	return &historyResp, nil
+}
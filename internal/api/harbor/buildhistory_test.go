@@ -0,0 +1,30 @@
+package harbor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchBuildHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"created": "2024-01-01T00:00:00Z", "created_by": "/bin/sh -c #(nop) ADD file:abc in / "},
+			{"created": "2024-01-01T00:00:01Z", "created_by": "/bin/sh -c #(nop)  CMD [\"bash\"]", "empty_layer": true}
+		]`))
+	}))
+	defer server.Close()
+
+	h := harborApiClient{client: server.Client(), baseUrl: server.URL}
+	history, err := h.FetchBuildHistory(context.Background(), AdditionLink{Href: "/api/v2.0/projects/library/repositories/app/artifacts/sha256:a/additions/build_history"})
+	if err != nil {
+		t.Fatalf("FetchBuildHistory() error = %v", err)
+	}
+	if len(*history) != 2 {
+		t.Fatalf("FetchBuildHistory() returned %d layers, want 2", len(*history))
+	}
+	if (*history)[0].EmptyLayer || !(*history)[1].EmptyLayer {
+		t.Errorf("FetchBuildHistory() empty layers = %t, %t, want false, true", (*history)[0].EmptyLayer, (*history)[1].EmptyLayer)
+	}
+}
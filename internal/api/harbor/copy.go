@@ -0,0 +1,31 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+)
+
+// CopyArtifact copies the artifact identified by reference in
+// srcProject/srcRepository into project/repository, keeping its tags.
+// Repositories are created on the fly when missing.
+func (h harborApiClient) CopyArtifact(ctx context.Context, project string, repository string, srcProject string, srcRepository string, reference string) error {
+	from := fmt.Sprintf("%s/%s@%s", srcProject, registryName(srcRepository), reference)
+	query := url.Values{}
+	query.Set("from", from)
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts?%s", h.baseUrl, project, repository, query.Encode())
+	slog.Debug(fmt.Sprintf("Copying artifact %s. URL: %s", from, url))
+	req, err := h.newRequest(ctx, "POST", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Artifact %s copied to %s/%s", from, project, repository))
+
+	return nil
+}
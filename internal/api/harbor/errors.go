@@ -0,0 +1,63 @@
+package harbor
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrForbidden          = errors.New("forbidden")
+	ErrNotFound           = errors.New("not found")
+	ErrConflict           = errors.New("conflict")
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrServer             = errors.New("server error")
+)
+
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error is returned by every client method when harbor answers with a non
+// successful status code. It carries the errors payload sent by the server
+// and matches the sentinel errors above through errors.Is.
+type Error struct {
+	StatusCode int
+	Errors     []ErrorDetail `json:"errors"`
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, d := range e.Errors {
+		if d.Message != "" {
+			messages = append(messages, d.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		return fmt.Sprintf("status code: %d", e.StatusCode)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e *Error) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrConflict
+	case e.StatusCode == http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case e.StatusCode >= 500:
+		return ErrServer
+	}
+	return nil
+}
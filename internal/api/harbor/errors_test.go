@@ -0,0 +1,103 @@
+package harbor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorUnwrap(t *testing.T) {
+	sentinels := []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrPreconditionFailed, ErrServer}
+
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{statusCode: http.StatusUnauthorized, want: ErrUnauthorized},
+		{statusCode: http.StatusForbidden, want: ErrForbidden},
+		{statusCode: http.StatusNotFound, want: ErrNotFound},
+		{statusCode: http.StatusConflict, want: ErrConflict},
+		{statusCode: http.StatusPreconditionFailed, want: ErrPreconditionFailed},
+		{statusCode: http.StatusInternalServerError, want: ErrServer},
+		{statusCode: http.StatusServiceUnavailable, want: ErrServer},
+		{statusCode: http.StatusBadRequest, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			// Wrapped the way the tui reports failures
+			err := fmt.Errorf("failed to fetch projects: %w", &Error{StatusCode: tt.statusCode})
+
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %t", err, sentinel, got)
+				}
+			}
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.statusCode {
+				t.Errorf("errors.As() did not find the *Error of status %d", tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		want string
+	}{
+		{
+			name: "no payload",
+			err:  &Error{StatusCode: http.StatusNotFound},
+			want: "status code: 404",
+		},
+		{
+			name: "empty messages",
+			err:  &Error{StatusCode: http.StatusBadRequest, Errors: []ErrorDetail{{Code: "BAD_REQUEST"}}},
+			want: "status code: 400",
+		},
+		{
+			name: "several messages",
+			err: &Error{StatusCode: http.StatusConflict, Errors: []ErrorDetail{
+				{Code: "CONFLICT", Message: "project already exists"},
+				{Code: "CONFLICT"},
+				{Code: "CONFLICT", Message: "try another name"},
+			}},
+			want: "project already exists; try another name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoDecodesErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"errors":[{"code":"FORBIDDEN","message":"forbidden to delete"}]}`)
+	}))
+	defer server.Close()
+
+	h := harborApiClient{client: server.Client(), baseUrl: server.URL}
+	req, err := h.newRequest(context.Background(), "GET", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = h.do(req, nil)
+	if !errors.Is(err, ErrForbidden) {
+		t.Fatalf("do() error = %v, want ErrForbidden", err)
+	}
+	if err.Error() != "forbidden to delete" {
+		t.Errorf("do() error = %q, want the message of the payload", err.Error())
+	}
+}
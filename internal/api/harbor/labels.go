@@ -0,0 +1,90 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+)
+
+const (
+	LabelScopeGlobal  = "g"
+	LabelScopeProject = "p"
+)
+
+type Label struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Color        string `json:"color"`
+	Scope        string `json:"scope"`
+	ProjectId    int    `json:"project_id"`
+	CreationTime string `json:"creation_time"`
+	UpdateTime   string `json:"update_time"`
+}
+
+type LabelRequestBody struct {
+	Id int `json:"id"`
+}
+
+// FetchLabels returns the global labels, or the labels of projectId when
+// scope is LabelScopeProject.
+func (h harborApiClient) FetchLabels(ctx context.Context, scope string, projectId int) (*[]Label, error) {
+	url := fmt.Sprintf("%s/api/v2.0/labels", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching labels. URL: %s", url))
+
+	query := urlValues("scope", scope)
+	if scope == LabelScopeProject {
+		query.Set("project_id", strconv.Itoa(projectId))
+	}
+
+	labelsResp, err := fetchAllPages[Label](ctx, h, url, query)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Labels fetched", "data", fmt.Sprintf("%+v", labelsResp))
+
+	return &labelsResp, nil
+}
+
+func (h harborApiClient) AddArtifactLabel(ctx context.Context, project string, repository string, reference string, labelId int) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s/labels", h.baseUrl, project, repository, reference)
+	slog.Debug(fmt.Sprintf("Adding label %d. URL: %s", labelId, url))
+	req, err := h.newRequest(ctx, "POST", url, LabelRequestBody{Id: labelId})
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Label %d added to artifact %s", labelId, reference))
+
+	return nil
+}
+
+func (h harborApiClient) RemoveArtifactLabel(ctx context.Context, project string, repository string, reference string, labelId int) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s/labels/%d", h.baseUrl, project, repository, reference, labelId)
+	slog.Debug(fmt.Sprintf("Removing label %d. URL: %s", labelId, url))
+	req, err := h.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Label %d removed from artifact %s", labelId, reference))
+
+	return nil
+}
+
+func urlValues(key string, value string) url.Values {
+	values := url.Values{}
+	values.Set(key, value)
+	return values
+}
@@ -0,0 +1,138 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// Project member roles.
+const (
+	RoleProjectAdmin = 1
+	RoleDeveloper    = 2
+	RoleGuest        = 3
+	RoleMaintainer   = 4
+	RoleLimitedGuest = 5
+)
+
+// Member entity types.
+const (
+	MemberTypeUser  = "u"
+	MemberTypeGroup = "g"
+)
+
+// GroupTypeLdap is the type of LDAP user groups.
+const GroupTypeLdap = 1
+
+type MemberResult struct {
+	Id         int    `json:"id"`
+	ProjectId  int    `json:"project_id"`
+	EntityName string `json:"entity_name"`
+	EntityId   int    `json:"entity_id"`
+	EntityType string `json:"entity_type"`
+	RoleId     int    `json:"role_id"`
+	RoleName   string `json:"role_name"`
+}
+
+type MemberUser struct {
+	Username string `json:"username,omitempty"`
+}
+
+type MemberGroup struct {
+	GroupName   string `json:"group_name,omitempty"`
+	GroupType   int    `json:"group_type,omitempty"`
+	LdapGroupDn string `json:"ldap_group_dn,omitempty"`
+}
+
+type MemberRequestBody struct {
+	RoleId      int          `json:"role_id"`
+	MemberUser  *MemberUser  `json:"member_user,omitempty"`
+	MemberGroup *MemberGroup `json:"member_group,omitempty"`
+}
+
+type MemberRoleRequestBody struct {
+	RoleId int `json:"role_id"`
+}
+
+func (h harborApiClient) FetchMembers(ctx context.Context, project string) (*[]MemberResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/members", h.baseUrl, project)
+	slog.Debug(fmt.Sprintf("Fetching members. URL: %s", url))
+
+	membersResp, err := fetchAllPages[MemberResult](ctx, h, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Members fetched", "data", fmt.Sprintf("%+v", membersResp))
+
+	return &membersResp, nil
+}
+
+// AddUserMember gives username the role identified by roleId in project.
+func (h harborApiClient) AddUserMember(ctx context.Context, project string, username string, roleId int) error {
+	return h.addMember(ctx, project, MemberRequestBody{
+		RoleId:     roleId,
+		MemberUser: &MemberUser{Username: username},
+	})
+}
+
+// AddLdapGroupMember gives the LDAP group identified by its dn the role
+// identified by roleId in project.
+func (h harborApiClient) AddLdapGroupMember(ctx context.Context, project string, groupDn string, roleId int) error {
+	return h.addMember(ctx, project, MemberRequestBody{
+		RoleId:      roleId,
+		MemberGroup: &MemberGroup{GroupType: GroupTypeLdap, LdapGroupDn: groupDn},
+	})
+}
+
+func (h harborApiClient) addMember(ctx context.Context, project string, member MemberRequestBody) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/members", h.baseUrl, project)
+	slog.Debug(fmt.Sprintf("Adding member. URL: %s", url))
+	req, err := h.newRequest(ctx, "POST", url, member)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Member added to project %s", project))
+
+	return nil
+}
+
+// UpdateMemberRole changes the role of the member identified by id.
+func (h harborApiClient) UpdateMemberRole(ctx context.Context, project string, id int, roleId int) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/members/%d", h.baseUrl, project, id)
+	slog.Debug(fmt.Sprintf("Updating member role. URL: %s", url))
+	req, err := h.newRequest(ctx, "PUT", url, MemberRoleRequestBody{RoleId: roleId})
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Member %d of project %s has now role %d", id, project, roleId))
+
+	return nil
+}
+
+func (h harborApiClient) DeleteMember(ctx context.Context, project string, id int) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/members/%d", h.baseUrl, project, id)
+	slog.Debug(fmt.Sprintf("Deleting member. URL: %s", url))
+	req, err := h.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Member %d removed from project %s", id, project))
+
+	return nil
+}
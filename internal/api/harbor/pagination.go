@@ -0,0 +1,85 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const pageSize = 100
+
+// fetchAllPages walks every page of a Harbor list endpoint and returns the
+// decoded items of all of them. Harbor announces the following page through
+// the Link header; X-Total-Count is used as a fallback when it is missing.
+func fetchAllPages[T any](ctx context.Context, h harborApiClient, endpoint string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("page", "1")
+	query.Set("page_size", strconv.Itoa(pageSize))
+
+	next := fmt.Sprintf("%s?%s", endpoint, query.Encode())
+	results := []T{}
+
+	for page := 1; next != ""; page++ {
+		slog.Debug(fmt.Sprintf("Fetching page %d. URL: %s", page, next))
+		req, err := h.newRequest(ctx, "GET", next, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []T
+		resp, err := h.do(req, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, items...)
+		next = h.nextPageUrl(resp.Header, endpoint, query, page, len(results), len(items))
+	}
+
+	return results, nil
+}
+
+// nextPageUrl returns the url of the page following page, or an empty string
+// once every item has been fetched.
+func (h harborApiClient) nextPageUrl(header http.Header, endpoint string, query url.Values, page int, fetched int, received int) string {
+	if link := nextLink(header.Get("Link")); link != "" {
+		if strings.HasPrefix(link, "/") {
+			return h.baseUrl + link
+		}
+		return link
+	}
+
+	total, err := strconv.Atoi(header.Get("X-Total-Count"))
+	if err != nil || received == 0 || fetched >= total {
+		return ""
+	}
+
+	query.Set("page", strconv.Itoa(page+1))
+	return fmt.Sprintf("%s?%s", endpoint, query.Encode())
+}
+
+// nextLink extracts the rel="next" target from a Link header such as
+// `</api/v2.0/projects?page=2&page_size=100>; rel="next"`.
+func nextLink(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		sections := strings.Split(part, ";")
+		if len(sections) < 2 {
+			continue
+		}
+
+		for _, param := range sections[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				target := strings.TrimSpace(sections[0])
+				return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+			}
+		}
+	}
+
+	return ""
+}
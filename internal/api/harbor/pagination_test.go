@@ -0,0 +1,165 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestFetchAllPages(t *testing.T) {
+	tests := []struct {
+		name string
+		// pages are the items answered for each page, starting at page 1
+		pages [][]int
+		// header sets the pagination headers of page out of pages
+		header func(h http.Header, page int, pages [][]int)
+		want   []int
+		// wantRequests is how many pages are fetched
+		wantRequests int
+	}{
+		{
+			name:  "relative link header",
+			pages: [][]int{{1, 2}, {3, 4}, {5}},
+			header: func(h http.Header, page int, pages [][]int) {
+				if page < len(pages) {
+					h.Set("Link", fmt.Sprintf(`</items?page=%d&page_size=100>; rel="next"`, page+1))
+				}
+			},
+			want:         []int{1, 2, 3, 4, 5},
+			wantRequests: 3,
+		},
+		{
+			name:  "link header with prev and next",
+			pages: [][]int{{1}, {2}, {3}},
+			header: func(h http.Header, page int, pages [][]int) {
+				links := ""
+				if page > 1 {
+					links = fmt.Sprintf(`</items?page=%d&page_size=100>; rel="prev"`, page-1)
+				}
+				if page < len(pages) {
+					if links != "" {
+						links += ", "
+					}
+					links += fmt.Sprintf(`</items?page=%d&page_size=100>; rel="next"`, page+1)
+				}
+				h.Set("Link", links)
+			},
+			want:         []int{1, 2, 3},
+			wantRequests: 3,
+		},
+		{
+			name:  "total count fallback",
+			pages: [][]int{{1, 2}, {3, 4}, {5}},
+			header: func(h http.Header, page int, pages [][]int) {
+				h.Set("X-Total-Count", "5")
+			},
+			want:         []int{1, 2, 3, 4, 5},
+			wantRequests: 3,
+		},
+		{
+			name:  "total count larger than the items",
+			pages: [][]int{{1, 2}, {}},
+			header: func(h http.Header, page int, pages [][]int) {
+				h.Set("X-Total-Count", "10")
+			},
+			want:         []int{1, 2},
+			wantRequests: 2,
+		},
+		{
+			name:         "no pagination headers",
+			pages:        [][]int{{1, 2}, {3}},
+			header:       func(h http.Header, page int, pages [][]int) {},
+			want:         []int{1, 2},
+			wantRequests: 1,
+		},
+		{
+			name:         "empty list",
+			pages:        [][]int{{}},
+			header:       func(h http.Header, page int, pages [][]int) { h.Set("X-Total-Count", "0") },
+			want:         []int{},
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if size := r.URL.Query().Get("page_size"); size != strconv.Itoa(pageSize) {
+					t.Errorf("page_size = %q, want %d", size, pageSize)
+				}
+				page, err := strconv.Atoi(r.URL.Query().Get("page"))
+				if err != nil || page < 1 || page > len(tt.pages) {
+					http.Error(w, "unexpected page", http.StatusBadRequest)
+					return
+				}
+
+				tt.header(w.Header(), page, tt.pages)
+				items := tt.pages[page-1]
+				body := "["
+				for i, item := range items {
+					if i > 0 {
+						body += ","
+					}
+					body += strconv.Itoa(item)
+				}
+				fmt.Fprint(w, body+"]")
+			}))
+			defer server.Close()
+
+			h := harborApiClient{client: server.Client(), baseUrl: server.URL}
+			got, err := fetchAllPages[int](context.Background(), h, server.URL+"/items", nil)
+			if err != nil {
+				t.Fatalf("fetchAllPages() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fetchAllPages() = %v, want %v", got, tt.want)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("fetchAllPages() made %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestFetchAllPagesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("X-Total-Count", "4")
+		fmt.Fprint(w, "[1,2]")
+	}))
+	defer server.Close()
+
+	h := harborApiClient{client: server.Client(), baseUrl: server.URL}
+	if _, err := fetchAllPages[int](context.Background(), h, server.URL+"/items", nil); err == nil {
+		t.Error("fetchAllPages() error = nil, want the error of the second page")
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: `</api/v2.0/projects?page=2&page_size=100>; rel="next"`, want: "/api/v2.0/projects?page=2&page_size=100"},
+		{header: `</p?page=1>; rel="prev" , </p?page=3>; rel="next"`, want: "/p?page=3"},
+		{header: `</p?page=1>; rel="prev"`, want: ""},
+		{header: `<https://harbor.example.com/p?page=2>;rel="next"`, want: "https://harbor.example.com/p?page=2"},
+		{header: "garbage", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := nextLink(tt.header); got != tt.want {
+			t.Errorf("nextLink(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
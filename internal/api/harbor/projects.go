@@ -1,10 +1,9 @@
 package harbor
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"log/slog"
-	"net/http"
 )
 
 type CveAllowlist struct {
@@ -15,13 +14,25 @@ type CveAllowlist struct {
 	UpdateTime   string `json:"update_time"`
 }
 
+// Metadata holds the project settings. Harbor encodes every value as a
+// string, booleans being "true" or "false". Empty values are left unchanged
+// on update.
 type Metadata struct {
-	AutoScan             string `json:"auto_scan"`
-	EnableContentTrust   string `json:"enable_content_trust"`
-	PreventVul           string `json:"prevent_vul"`
-	Public               string `json:"public"`
-	ReuseSysCveAllowlist string `json:"reuse_sys_cve_allowlist"`
-	Severity             string `json:"severity"`
+	AutoScan             string `json:"auto_scan,omitempty"`
+	EnableContentTrust   string `json:"enable_content_trust,omitempty"`
+	PreventVul           string `json:"prevent_vul,omitempty"`
+	Public               string `json:"public,omitempty"`
+	ReuseSysCveAllowlist string `json:"reuse_sys_cve_allowlist,omitempty"`
+	Severity             string `json:"severity,omitempty"`
+}
+
+// Severities a project can prevent vulnerable images from being pulled at.
+var ProjectSeverities = []string{"none", "low", "medium", "high", "critical"}
+
+type ProjectRequestBody struct {
+	ProjectName  string   `json:"project_name,omitempty"`
+	Metadata     Metadata `json:"metadata"`
+	StorageLimit *int64   `json:"storage_limit,omitempty"`
 }
 
 type ProjectsResult struct {
@@ -39,40 +50,100 @@ type ProjectsResult struct {
 	UpdateTime         string       `json:"update_time"`
 }
 
-func (h harborApiClient) FetchProjects() (*[]ProjectsResult, error) {
+func (h harborApiClient) FetchProjects(ctx context.Context, opts QueryOptions) (*[]ProjectsResult, error) {
 	url := fmt.Sprintf("%s/api/v2.0/projects", h.baseUrl)
 	slog.Debug(fmt.Sprintf("Fetching projects. URL: %s", url))
-	req, err := http.NewRequest("GET", url, nil)
+
+	query, err := opts.Values()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", h.credentials))
-	req.Header.Set("User-Agent", "harborw/1.0")
+	projectsResp, err := fetchAllPages[ProjectsResult](ctx, h, url, query)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Projects fetched", "data", fmt.Sprintf("%+v", projectsResp))
 
-	q := req.URL.Query()
-	q.Add("page", "1")
-	q.Add("page_size", "100")
-	req.URL.RawQuery = q.Encode()
+	return &projectsResp, nil
+}
 
-	resp, err := h.client.Do(req)
+func (h harborApiClient) FetchProject(ctx context.Context, project string) (*ProjectsResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s", h.baseUrl, project)
+	slog.Debug(fmt.Sprintf("Fetching project. URL: %s", url))
+	req, err := h.newRequest(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+	var projectResp ProjectsResult
+	if _, err := h.do(req, &projectResp); err != nil {
+		return nil, err
 	}
 
-	var projectsResp []ProjectsResult
-	if err := json.NewDecoder(resp.Body).Decode(&projectsResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	slog.Debug("Project fetched", "data", fmt.Sprintf("%+v", projectResp))
+
+	return &projectResp, nil
+}
+
+// CreateProject creates project with metadata. storageLimit is in bytes,
+// StorageUnlimited for no quota.
+func (h harborApiClient) CreateProject(ctx context.Context, project string, metadata Metadata, storageLimit int64) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Creating project %s. URL: %s", project, url))
+	body := ProjectRequestBody{
+		ProjectName:  project,
+		Metadata:     metadata,
+		StorageLimit: &storageLimit,
+	}
+	req, err := h.newRequest(ctx, "POST", url, body)
+	if err != nil {
+		return err
 	}
 
-	slog.Debug("Projects fetched", "data", fmt.Sprintf("%+v", projectsResp))
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
 
-	return &projectsResp, nil
+	slog.Debug(fmt.Sprintf("Project %s created", project))
+
+	return nil
+}
+
+// UpdateProject changes the settings of project present in metadata.
+func (h harborApiClient) UpdateProject(ctx context.Context, project string, metadata Metadata) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s", h.baseUrl, project)
+	slog.Debug(fmt.Sprintf("Updating project %s. URL: %s", project, url))
+	req, err := h.newRequest(ctx, "PUT", url, ProjectRequestBody{Metadata: metadata})
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Project %s updated", project), "data", fmt.Sprintf("%+v", metadata))
+
+	return nil
+}
+
+// DeleteProject deletes project. Harbor refuses to delete projects that
+// still hold repositories.
+func (h harborApiClient) DeleteProject(ctx context.Context, project string) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s", h.baseUrl, project)
+	slog.Debug(fmt.Sprintf("Deleting project %s. URL: %s", project, url))
+	req, err := h.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Project %s deleted", project))
+
+	return nil
 }
@@ -0,0 +1,137 @@
+package harbor
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TimeRange bounds a time based filter. A zero From or To leaves that side
+// of the range open.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+func (r TimeRange) isZero() bool {
+	return r.From.IsZero() && r.To.IsZero()
+}
+
+func (r TimeRange) String() string {
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.DateTime)
+	}
+	return fmt.Sprintf("[%s~%s]", format(r.From), format(r.To))
+}
+
+// QueryOptions filters and sorts harbor list calls server side using the
+// q and sort query parameters. Filters that an endpoint does not support
+// are ignored by harbor.
+type QueryOptions struct {
+	// Name is matched fuzzily against the project or repository name.
+	Name string
+	// Tag is matched fuzzily against the artifact tags.
+	Tag string
+	// Tagged keeps only tagged artifacts when true and only untagged ones
+	// when false. Nil disables the filter.
+	Tagged   *bool
+	PushTime TimeRange
+	PullTime TimeRange
+	// LabelIds keeps artifacts having all of the given labels.
+	LabelIds []int
+	// Operation keeps audit logs of the given operation, e.g. "delete".
+	Operation string
+	// Resource and Username are matched fuzzily against audit logs.
+	Resource string
+	Username string
+	OpTime   TimeRange
+	// Sort lists fields to sort by, prefixed with - for descending order,
+	// e.g. "-push_time".
+	Sort []string
+}
+
+// validate refuses filters holding the separators of the q parameter, which
+// Harbor offers no way to escape.
+func (o QueryOptions) validate() error {
+	filters := []struct {
+		key   string
+		value string
+	}{
+		{"name", o.Name},
+		{"tag", o.Tag},
+		{"operation", o.Operation},
+		{"resource", o.Resource},
+		{"user", o.Username},
+	}
+	for _, f := range filters {
+		if strings.ContainsAny(f.value, ",=~") {
+			return fmt.Errorf("invalid %s filter %q, it cannot contain \",\", \"=\" or \"~\"", f.key, f.value)
+		}
+	}
+	return nil
+}
+
+// Values encodes the options as query parameters, failing when a filter
+// cannot be expressed in Harbor's query syntax.
+func (o QueryOptions) Values() (url.Values, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+
+	query := []string{}
+	if o.Name != "" {
+		query = append(query, fmt.Sprintf("name=~%s", o.Name))
+	}
+	if o.Tag != "" {
+		query = append(query, fmt.Sprintf("tags=~%s", o.Tag))
+	}
+	if o.Tagged != nil {
+		if *o.Tagged {
+			query = append(query, "tags=*")
+		} else {
+			query = append(query, "tags=nil")
+		}
+	}
+	if !o.PushTime.isZero() {
+		query = append(query, fmt.Sprintf("push_time=%s", o.PushTime))
+	}
+	if !o.PullTime.isZero() {
+		query = append(query, fmt.Sprintf("pull_time=%s", o.PullTime))
+	}
+	if len(o.LabelIds) > 0 {
+		ids := make([]string, len(o.LabelIds))
+		for i, id := range o.LabelIds {
+			ids[i] = fmt.Sprint(id)
+		}
+		// Parentheses ask for every label, braces would ask for any of them
+		query = append(query, fmt.Sprintf("labels=(%s)", strings.Join(ids, " ")))
+	}
+
+	if o.Operation != "" {
+		query = append(query, fmt.Sprintf("operation=%s", o.Operation))
+	}
+	if o.Resource != "" {
+		query = append(query, fmt.Sprintf("resource=~%s", o.Resource))
+	}
+	if o.Username != "" {
+		query = append(query, fmt.Sprintf("username=~%s", o.Username))
+	}
+	if !o.OpTime.isZero() {
+		query = append(query, fmt.Sprintf("op_time=%s", o.OpTime))
+	}
+
+	if len(query) > 0 {
+		values.Set("q", strings.Join(query, ","))
+	}
+	if len(o.Sort) > 0 {
+		values.Set("sort", strings.Join(o.Sort, ","))
+	}
+
+	return values, nil
+}
@@ -0,0 +1,98 @@
+package harbor
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestQueryOptionsValues(t *testing.T) {
+	yes, no := true, false
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		opts    QueryOptions
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			opts: QueryOptions{},
+			want: url.Values{},
+		},
+		{
+			name: "name",
+			opts: QueryOptions{Name: "library"},
+			want: url.Values{"q": {"name=~library"}},
+		},
+		{
+			name: "tag and tagged",
+			opts: QueryOptions{Tag: "v1", Tagged: &yes},
+			want: url.Values{"q": {"tags=~v1,tags=*"}},
+		},
+		{
+			name: "untagged",
+			opts: QueryOptions{Tagged: &no},
+			want: url.Values{"q": {"tags=nil"}},
+		},
+		{
+			name: "open time ranges",
+			opts: QueryOptions{PushTime: TimeRange{From: from}, PullTime: TimeRange{To: to}},
+			want: url.Values{"q": {"push_time=[2024-01-01 00:00:00~],pull_time=[~2024-01-31 12:30:00]"}},
+		},
+		{
+			name: "local time converted to utc",
+			opts: QueryOptions{OpTime: TimeRange{From: from.In(time.FixedZone("UTC+2", 2*60*60)), To: to}},
+			want: url.Values{"q": {"op_time=[2024-01-01 00:00:00~2024-01-31 12:30:00]"}},
+		},
+		{
+			name: "every label",
+			opts: QueryOptions{LabelIds: []int{1, 2}},
+			want: url.Values{"q": {"labels=(1 2)"}},
+		},
+		{
+			name: "audit filters",
+			opts: QueryOptions{Operation: "delete", Resource: "library/nginx", Username: "bob"},
+			want: url.Values{"q": {"operation=delete,resource=~library/nginx,username=~bob"}},
+		},
+		{
+			name: "sort only",
+			opts: QueryOptions{Sort: []string{"-push_time", "name"}},
+			want: url.Values{"sort": {"-push_time,name"}},
+		},
+		{
+			name:    "comma in name",
+			opts:    QueryOptions{Name: "a,tags=*"},
+			wantErr: true,
+		},
+		{
+			name:    "equal sign in tag",
+			opts:    QueryOptions{Tag: "v1=2"},
+			wantErr: true,
+		},
+		{
+			name:    "tilde in resource",
+			opts:    QueryOptions{Resource: "library/~nginx"},
+			wantErr: true,
+		},
+		{
+			name:    "comma in user",
+			opts:    QueryOptions{Username: "bob,alice"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.Values()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Values() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got.Encode() != tt.want.Encode() {
+				t.Errorf("Values() = %q, want %q", got.Encode(), tt.want.Encode())
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// StorageUnlimited is the hard storage limit of a project without quota.
+const StorageUnlimited = -1
+
+type ResourceList struct {
+	Storage int64 `json:"storage"`
+}
+
+type QuotaRef struct {
+	Id        int    `json:"id"`
+	Name      string `json:"name"`
+	OwnerName string `json:"owner_name"`
+}
+
+type QuotaResult struct {
+	Id           int          `json:"id"`
+	Ref          QuotaRef     `json:"ref"`
+	Hard         ResourceList `json:"hard"`
+	Used         ResourceList `json:"used"`
+	CreationTime string       `json:"creation_time"`
+	UpdateTime   string       `json:"update_time"`
+}
+
+type Quota struct {
+	Hard ResourceList `json:"hard"`
+	Used ResourceList `json:"used"`
+}
+
+type ProjectSummaryResult struct {
+	RepoCount         int   `json:"repo_count"`
+	ProjectAdminCount int   `json:"project_admin_count"`
+	MaintainerCount   int   `json:"maintainer_count"`
+	DeveloperCount    int   `json:"developer_count"`
+	GuestCount        int   `json:"guest_count"`
+	LimitedGuestCount int   `json:"limited_guest_count"`
+	Quota             Quota `json:"quota"`
+}
+
+// FetchQuotas returns the storage quota of every project.
+func (h harborApiClient) FetchQuotas(ctx context.Context) (*[]QuotaResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/quotas", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching quotas. URL: %s", url))
+
+	quotasResp, err := fetchAllPages[QuotaResult](ctx, h, url, urlValues("reference", "project"))
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Quotas fetched", "data", fmt.Sprintf("%+v", quotasResp))
+
+	return &quotasResp, nil
+}
+
+type QuotaRequestBody struct {
+	Hard ResourceList `json:"hard"`
+}
+
+// FetchProjectQuota returns the storage quota of the project identified by
+// projectId.
+func (h harborApiClient) FetchProjectQuota(ctx context.Context, projectId int) (*QuotaResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/quotas", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching project quota. URL: %s", url))
+
+	query := urlValues("reference", "project")
+	query.Set("reference_id", strconv.Itoa(projectId))
+	quotasResp, err := fetchAllPages[QuotaResult](ctx, h, url, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(quotasResp) == 0 {
+		return nil, fmt.Errorf("project %d has no quota: %w", projectId, ErrNotFound)
+	}
+
+	slog.Debug("Project quota fetched", "data", fmt.Sprintf("%+v", quotasResp[0]))
+
+	return &quotasResp[0], nil
+}
+
+// UpdateQuota sets the storage limit, in bytes, of the quota identified by
+// id. StorageUnlimited removes the limit.
+func (h harborApiClient) UpdateQuota(ctx context.Context, id int, storage int64) error {
+	url := fmt.Sprintf("%s/api/v2.0/quotas/%d", h.baseUrl, id)
+	slog.Debug(fmt.Sprintf("Updating quota. URL: %s", url))
+	req, err := h.newRequest(ctx, "PUT", url, QuotaRequestBody{Hard: ResourceList{Storage: storage}})
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Quota %d set to %d bytes", id, storage))
+
+	return nil
+}
+
+func (h harborApiClient) FetchProjectSummary(ctx context.Context, project string) (*ProjectSummaryResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/summary", h.baseUrl, project)
+	slog.Debug(fmt.Sprintf("Fetching project summary. URL: %s", url))
+
+	var summaryResp ProjectSummaryResult
+	if err := h.get(ctx, url, &summaryResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Project summary fetched", "data", fmt.Sprintf("%+v", summaryResp))
+
+	return &summaryResp, nil
+}
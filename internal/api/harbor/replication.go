@@ -0,0 +1,239 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"strconv"
+)
+
+// Replication execution and task statuses.
+const (
+	ReplicationStatusInProgress = "InProgress"
+	ReplicationStatusSucceed    = "Succeed"
+	ReplicationStatusFailed     = "Failed"
+	ReplicationStatusStopped    = "Stopped"
+)
+
+type RegistryCredential struct {
+	Type         string `json:"type"`
+	AccessKey    string `json:"access_key"`
+	AccessSecret string `json:"access_secret"`
+}
+
+type RegistryResult struct {
+	Id           int                `json:"id"`
+	Name         string             `json:"name"`
+	Url          string             `json:"url"`
+	Type         string             `json:"type"`
+	Status       string             `json:"status"`
+	Description  string             `json:"description"`
+	Insecure     bool               `json:"insecure"`
+	Credential   RegistryCredential `json:"credential"`
+	CreationTime string             `json:"creation_time"`
+	UpdateTime   string             `json:"update_time"`
+}
+
+type ReplicationFilter struct {
+	Type       string `json:"type"`
+	Value      any    `json:"value"`
+	Decoration string `json:"decoration"`
+}
+
+type TriggerSettings struct {
+	Cron string `json:"cron"`
+}
+
+type ReplicationTrigger struct {
+	Type            string          `json:"type"`
+	TriggerSettings TriggerSettings `json:"trigger_settings"`
+}
+
+type ReplicationPolicyResult struct {
+	Id            int                 `json:"id"`
+	Name          string              `json:"name"`
+	Description   string              `json:"description"`
+	SrcRegistry   *RegistryResult     `json:"src_registry"`
+	DestRegistry  *RegistryResult     `json:"dest_registry"`
+	DestNamespace string              `json:"dest_namespace"`
+	Filters       []ReplicationFilter `json:"filters"`
+	Trigger       *ReplicationTrigger `json:"trigger"`
+	Deletion      bool                `json:"deletion"`
+	Override      bool                `json:"override"`
+	Enabled       bool                `json:"enabled"`
+	Speed         int                 `json:"speed"`
+	CreationTime  string              `json:"creation_time"`
+	UpdateTime    string              `json:"update_time"`
+}
+
+type ReplicationExecutionResult struct {
+	Id         int    `json:"id"`
+	PolicyId   int    `json:"policy_id"`
+	Status     string `json:"status"`
+	StatusText string `json:"status_text"`
+	Trigger    string `json:"trigger"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+	Total      int    `json:"total"`
+	Failed     int    `json:"failed"`
+	Succeed    int    `json:"succeed"`
+	InProgress int    `json:"in_progress"`
+	Stopped    int    `json:"stopped"`
+}
+
+func (e ReplicationExecutionResult) IsRunning() bool {
+	return e.Status == ReplicationStatusInProgress
+}
+
+type ReplicationTaskResult struct {
+	Id           int    `json:"id"`
+	ExecutionId  int    `json:"execution_id"`
+	Status       string `json:"status"`
+	JobId        string `json:"job_id"`
+	Operation    string `json:"operation"`
+	ResourceType string `json:"resource_type"`
+	SrcResource  string `json:"src_resource"`
+	DstResource  string `json:"dst_resource"`
+	StartTime    string `json:"start_time"`
+	EndTime      string `json:"end_time"`
+}
+
+type ReplicationExecutionRequestBody struct {
+	PolicyId int `json:"policy_id"`
+}
+
+// FetchRegistries returns the registries harbor can replicate from or to.
+func (h harborApiClient) FetchRegistries(ctx context.Context) (*[]RegistryResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/registries", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching registries. URL: %s", url))
+
+	registriesResp, err := fetchAllPages[RegistryResult](ctx, h, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Registries fetched", "data", fmt.Sprintf("%+v", registriesResp))
+
+	return &registriesResp, nil
+}
+
+func (h harborApiClient) FetchReplicationPolicies(ctx context.Context) (*[]ReplicationPolicyResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/replication/policies", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching replication policies. URL: %s", url))
+
+	policiesResp, err := fetchAllPages[ReplicationPolicyResult](ctx, h, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Replication policies fetched", "data", fmt.Sprintf("%+v", policiesResp))
+
+	return &policiesResp, nil
+}
+
+// StartReplication runs the policy identified by policyId manually and
+// returns the id of the new execution.
+func (h harborApiClient) StartReplication(ctx context.Context, policyId int) (int, error) {
+	url := fmt.Sprintf("%s/api/v2.0/replication/executions", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Starting replication of policy %d. URL: %s", policyId, url))
+	req, err := h.newRequest(ctx, "POST", url, ReplicationExecutionRequestBody{PolicyId: policyId})
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := h.do(req, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// The new execution is only given through its location
+	location := resp.Header.Get("Location")
+	id, err := strconv.Atoi(path.Base(location))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected execution location %q: %w", location, err)
+	}
+
+	slog.Debug(fmt.Sprintf("Replication execution %d started", id))
+
+	return id, nil
+}
+
+// StopReplication stops the execution identified by id.
+func (h harborApiClient) StopReplication(ctx context.Context, id int) error {
+	url := fmt.Sprintf("%s/api/v2.0/replication/executions/%d", h.baseUrl, id)
+	slog.Debug(fmt.Sprintf("Stopping replication execution. URL: %s", url))
+	req, err := h.newRequest(ctx, "PUT", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Replication execution %d stopped", id))
+
+	return nil
+}
+
+// FetchReplicationExecutions returns the latest limit executions of the
+// policy identified by policyId, most recent first.
+func (h harborApiClient) FetchReplicationExecutions(ctx context.Context, policyId int, limit int) (*[]ReplicationExecutionResult, error) {
+	query := urlValues("policy_id", strconv.Itoa(policyId))
+	query.Set("sort", "-start_time")
+	query.Set("page", "1")
+	query.Set("page_size", strconv.Itoa(limit))
+	url := fmt.Sprintf("%s/api/v2.0/replication/executions?%s", h.baseUrl, query.Encode())
+	slog.Debug(fmt.Sprintf("Fetching replication executions. URL: %s", url))
+
+	var executionsResp []ReplicationExecutionResult
+	if err := h.get(ctx, url, &executionsResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Replication executions fetched", "data", fmt.Sprintf("%+v", executionsResp))
+
+	return &executionsResp, nil
+}
+
+func (h harborApiClient) FetchReplicationExecution(ctx context.Context, id int) (*ReplicationExecutionResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/replication/executions/%d", h.baseUrl, id)
+	slog.Debug(fmt.Sprintf("Fetching replication execution. URL: %s", url))
+
+	var executionResp ReplicationExecutionResult
+	if err := h.get(ctx, url, &executionResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Replication execution fetched", "data", fmt.Sprintf("%+v", executionResp))
+
+	return &executionResp, nil
+}
+
+func (h harborApiClient) FetchReplicationTasks(ctx context.Context, executionId int) (*[]ReplicationTaskResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/replication/executions/%d/tasks", h.baseUrl, executionId)
+	slog.Debug(fmt.Sprintf("Fetching replication tasks. URL: %s", url))
+
+	tasksResp, err := fetchAllPages[ReplicationTaskResult](ctx, h, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Replication tasks fetched", "data", fmt.Sprintf("%+v", tasksResp))
+
+	return &tasksResp, nil
+}
+
+// FetchReplicationTaskLog returns the plain text log of a replication task.
+func (h harborApiClient) FetchReplicationTaskLog(ctx context.Context, executionId int, taskId int) (string, error) {
+	url := fmt.Sprintf("%s/api/v2.0/replication/executions/%d/tasks/%d/log", h.baseUrl, executionId, taskId)
+	slog.Debug(fmt.Sprintf("Fetching replication task log. URL: %s", url))
+
+	var log []byte
+	if err := h.get(ctx, url, &log); err != nil {
+		return "", err
+	}
+
+	return string(log), nil
+}
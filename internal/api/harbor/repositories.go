@@ -1,10 +1,9 @@
 package harbor
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"log/slog"
-	"net/http"
 )
 
 type RepositoriesResult struct {
@@ -17,40 +16,39 @@ type RepositoriesResult struct {
 	UpdateTime    string `json:"update_time"`
 }
 
-func (h harborApiClient) FetchRepositories(project string) (*[]RepositoriesResult, error) {
+func (h harborApiClient) FetchRepositories(ctx context.Context, project string, opts QueryOptions) (*[]RepositoriesResult, error) {
 	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories", h.baseUrl, project)
 	slog.Debug(fmt.Sprintf("Fetching repositories. URL: %s", url))
-	req, err := http.NewRequest("GET", url, nil)
+
+	query, err := opts.Values()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", h.credentials))
-	req.Header.Set("User-Agent", "harborw/1.0")
-
-	q := req.URL.Query()
-	q.Add("page", "1")
-	q.Add("page_size", "100")
-	req.URL.RawQuery = q.Encode()
-
-	resp, err := h.client.Do(req)
+	repositoriesResp, err := fetchAllPages[RepositoriesResult](ctx, h, url, query)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+	slog.Debug("Repositories fetched", "data", fmt.Sprintf("%+v", repositoriesResp))
+
+	return &repositoriesResp, nil
+}
+
+// DeleteRepository deletes repository along with all its artifacts and tags.
+func (h harborApiClient) DeleteRepository(ctx context.Context, project string, repository string) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s", h.baseUrl, project, repository)
+	slog.Debug(fmt.Sprintf("Deleting repository. URL: %s", url))
+	req, err := h.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
 	}
 
-	var repositoriesResp []RepositoriesResult
-	if err := json.NewDecoder(resp.Body).Decode(&repositoriesResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if _, err := h.do(req, nil); err != nil {
+		return err
 	}
 
-	slog.Debug("Repositories fetched", "data", fmt.Sprintf("%+v", repositoriesResp))
+	slog.Debug(fmt.Sprintf("Repository %s/%s deleted", project, repository))
 
-	return &repositoriesResp, nil
+	return nil
 }
@@ -0,0 +1,170 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Robot account levels.
+const (
+	RobotLevelSystem  = "system"
+	RobotLevelProject = "project"
+)
+
+// RobotNeverExpires is the duration, and expiry, of robots that never expire.
+const RobotNeverExpires = -1
+
+type RobotAccess struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+type RobotPermission struct {
+	Kind      string        `json:"kind"`
+	Namespace string        `json:"namespace"`
+	Access    []RobotAccess `json:"access"`
+}
+
+type RobotResult struct {
+	Id           int               `json:"id"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	Secret       string            `json:"secret,omitempty"`
+	Level        string            `json:"level"`
+	Duration     int               `json:"duration"`
+	Editable     bool              `json:"editable"`
+	Disable      bool              `json:"disable"`
+	ExpiresAt    int64             `json:"expires_at"`
+	Permissions  []RobotPermission `json:"permissions"`
+	CreationTime string            `json:"creation_time"`
+	UpdateTime   string            `json:"update_time"`
+}
+
+// Expires returns when the robot expires and false when it never does.
+func (r RobotResult) Expires() (time.Time, bool) {
+	if r.ExpiresAt == RobotNeverExpires || r.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(r.ExpiresAt, 0), true
+}
+
+type RobotCreateRequestBody struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Level       string            `json:"level"`
+	Duration    int               `json:"duration"`
+	Disable     bool              `json:"disable"`
+	Permissions []RobotPermission `json:"permissions"`
+}
+
+type RobotCreatedResult struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	Secret       string `json:"secret"`
+	CreationTime string `json:"creation_time"`
+	ExpiresAt    int64  `json:"expires_at"`
+}
+
+type RobotSecret struct {
+	Secret string `json:"secret"`
+}
+
+// FetchRobots returns the robot accounts of the project identified by
+// projectId, or the system robot accounts when projectId is 0.
+func (h harborApiClient) FetchRobots(ctx context.Context, projectId int) (*[]RobotResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/robots", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching robots. URL: %s", url))
+
+	query := fmt.Sprintf("Level=%s", RobotLevelSystem)
+	if projectId != 0 {
+		query = fmt.Sprintf("Level=%s,ProjectID=%d", RobotLevelProject, projectId)
+	}
+
+	robotsResp, err := fetchAllPages[RobotResult](ctx, h, url, urlValues("q", query))
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Robots fetched", "data", fmt.Sprintf("%+v", robotsResp))
+
+	return &robotsResp, nil
+}
+
+// CreateRobot creates a robot account. Its secret is only ever returned
+// here.
+func (h harborApiClient) CreateRobot(ctx context.Context, robot RobotCreateRequestBody) (*RobotCreatedResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/robots", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Creating robot %s. URL: %s", robot.Name, url))
+	req, err := h.newRequest(ctx, "POST", url, robot)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdResp RobotCreatedResult
+	if _, err := h.do(req, &createdResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Robot %s created", createdResp.Name))
+
+	return &createdResp, nil
+}
+
+// RefreshRobotSecret replaces the secret of the robot identified by id with
+// a generated one and returns it.
+func (h harborApiClient) RefreshRobotSecret(ctx context.Context, id int) (string, error) {
+	url := fmt.Sprintf("%s/api/v2.0/robots/%d", h.baseUrl, id)
+	slog.Debug(fmt.Sprintf("Refreshing robot secret. URL: %s", url))
+	req, err := h.newRequest(ctx, "PATCH", url, RobotSecret{})
+	if err != nil {
+		return "", err
+	}
+
+	var secretResp RobotSecret
+	if _, err := h.do(req, &secretResp); err != nil {
+		return "", err
+	}
+
+	slog.Debug(fmt.Sprintf("Secret of robot %d refreshed", id))
+
+	return secretResp.Secret, nil
+}
+
+// UpdateRobot replaces the robot account identified by robot.Id, e.g. to
+// disable it.
+func (h harborApiClient) UpdateRobot(ctx context.Context, robot RobotResult) error {
+	url := fmt.Sprintf("%s/api/v2.0/robots/%d", h.baseUrl, robot.Id)
+	slog.Debug(fmt.Sprintf("Updating robot %s. URL: %s", robot.Name, url))
+	req, err := h.newRequest(ctx, "PUT", url, robot)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Robot %s updated", robot.Name))
+
+	return nil
+}
+
+func (h harborApiClient) DeleteRobot(ctx context.Context, id int) error {
+	url := fmt.Sprintf("%s/api/v2.0/robots/%d", h.baseUrl, id)
+	slog.Debug(fmt.Sprintf("Deleting robot. URL: %s", url))
+	req, err := h.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Robot %d deleted", id))
+
+	return nil
+}
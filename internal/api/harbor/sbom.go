@@ -0,0 +1,144 @@
+package harbor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+const (
+	SbomFormatSpdx      = "SPDX"
+	SbomFormatCycloneDx = "CycloneDX"
+)
+
+type SbomPackage struct {
+	Name     string
+	Version  string
+	Licenses []string
+}
+
+type Sbom struct {
+	Format   string
+	Packages []SbomPackage
+}
+
+type spdxDocument struct {
+	SpdxVersion string `json:"spdxVersion"`
+	Packages    []struct {
+		Name             string `json:"name"`
+		VersionInfo      string `json:"versionInfo"`
+		LicenseConcluded string `json:"licenseConcluded"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+	} `json:"packages"`
+}
+
+type cycloneDxDocument struct {
+	BomFormat  string `json:"bomFormat"`
+	Components []struct {
+		Name     string `json:"name"`
+		Version  string `json:"version"`
+		Licenses []struct {
+			License struct {
+				Id   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"license"`
+			Expression string `json:"expression"`
+		} `json:"licenses"`
+	} `json:"components"`
+}
+
+// ParseSbom decodes an SPDX or CycloneDX json document.
+func ParseSbom(data []byte) (*Sbom, error) {
+	var cycloneDx cycloneDxDocument
+	if err := json.Unmarshal(data, &cycloneDx); err == nil && cycloneDx.BomFormat == SbomFormatCycloneDx {
+		sbom := &Sbom{Format: SbomFormatCycloneDx}
+		for _, c := range cycloneDx.Components {
+			licenses := []string{}
+			for _, l := range c.Licenses {
+				switch {
+				case l.Expression != "":
+					licenses = append(licenses, l.Expression)
+				case l.License.Id != "":
+					licenses = append(licenses, l.License.Id)
+				case l.License.Name != "":
+					licenses = append(licenses, l.License.Name)
+				}
+			}
+			sbom.Packages = append(sbom.Packages, SbomPackage{c.Name, c.Version, licenses})
+		}
+		return sbom, nil
+	}
+
+	var spdx spdxDocument
+	if err := json.Unmarshal(data, &spdx); err == nil && spdx.SpdxVersion != "" {
+		sbom := &Sbom{Format: SbomFormatSpdx}
+		for _, p := range spdx.Packages {
+			licenses := []string{}
+			for _, l := range []string{p.LicenseConcluded, p.LicenseDeclared} {
+				if l != "" && l != "NOASSERTION" && l != "NONE" {
+					licenses = append(licenses, l)
+					break
+				}
+			}
+			sbom.Packages = append(sbom.Packages, SbomPackage{p.Name, p.VersionInfo, licenses})
+		}
+		return sbom, nil
+	}
+
+	return nil, fmt.Errorf("unsupported sbom format, expected SPDX or CycloneDX json")
+}
+
+type manifestLayer struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int    `json:"size"`
+}
+
+type manifest struct {
+	Layers []manifestLayer `json:"layers"`
+}
+
+// FetchSbom downloads the document stored in an SBOM accessory through the
+// registry api and parses it.
+func (h harborApiClient) FetchSbom(ctx context.Context, project string, repository string, accessory Accessory) (*Sbom, error) {
+	name := fmt.Sprintf("%s/%s", project, registryName(repository))
+	url := fmt.Sprintf("%s/v2/%s/manifests/%s", h.baseUrl, name, accessory.Digest)
+	slog.Debug(fmt.Sprintf("Fetching sbom manifest. URL: %s", url))
+	req, err := h.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json")
+
+	var m manifest
+	if _, err := h.do(req, &m); err != nil {
+		return nil, err
+	}
+
+	if len(m.Layers) == 0 {
+		return nil, fmt.Errorf("sbom %s has no content", accessory.Digest)
+	}
+
+	url = fmt.Sprintf("%s/v2/%s/blobs/%s", h.baseUrl, name, m.Layers[0].Digest)
+	slog.Debug(fmt.Sprintf("Fetching sbom document. URL: %s", url))
+	req, err = h.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	var document json.RawMessage
+	if _, err := h.do(req, &document); err != nil {
+		return nil, err
+	}
+
+	sbom, err := ParseSbom(document)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Sbom fetched", "format", sbom.Format, "packages", len(sbom.Packages))
+
+	return sbom, nil
+}
@@ -0,0 +1,71 @@
+package harbor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSbom(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     *Sbom
+		wantErr  bool
+	}{
+		{
+			name: "spdx",
+			document: `{
+				"spdxVersion": "SPDX-2.3",
+				"packages": [
+					{"name": "openssl", "versionInfo": "3.0.13", "licenseConcluded": "Apache-2.0", "licenseDeclared": "Apache-2.0"},
+					{"name": "musl", "versionInfo": "1.2.4", "licenseConcluded": "NOASSERTION", "licenseDeclared": "MIT"},
+					{"name": "busybox", "versionInfo": "1.36.1", "licenseConcluded": "NONE", "licenseDeclared": "NOASSERTION"}
+				]
+			}`,
+			want: &Sbom{Format: SbomFormatSpdx, Packages: []SbomPackage{
+				{Name: "openssl", Version: "3.0.13", Licenses: []string{"Apache-2.0"}},
+				{Name: "musl", Version: "1.2.4", Licenses: []string{"MIT"}},
+				{Name: "busybox", Version: "1.36.1", Licenses: []string{}},
+			}},
+		},
+		{
+			name: "cyclonedx",
+			document: `{
+				"bomFormat": "CycloneDX",
+				"specVersion": "1.5",
+				"components": [
+					{"name": "lodash", "version": "4.17.21", "licenses": [{"license": {"id": "MIT"}}]},
+					{"name": "left-pad", "version": "1.3.0", "licenses": [{"license": {"name": "WTFPL"}}]},
+					{"name": "glibc", "version": "2.36", "licenses": [{"expression": "GPL-2.0-or-later AND LGPL-2.1-or-later"}]},
+					{"name": "app", "version": "1.0.0"}
+				]
+			}`,
+			want: &Sbom{Format: SbomFormatCycloneDx, Packages: []SbomPackage{
+				{Name: "lodash", Version: "4.17.21", Licenses: []string{"MIT"}},
+				{Name: "left-pad", Version: "1.3.0", Licenses: []string{"WTFPL"}},
+				{Name: "glibc", Version: "2.36", Licenses: []string{"GPL-2.0-or-later AND LGPL-2.1-or-later"}},
+				{Name: "app", Version: "1.0.0", Licenses: []string{}},
+			}},
+		},
+		{
+			name:     "spdx without packages",
+			document: `{"spdxVersion": "SPDX-2.3"}`,
+			want:     &Sbom{Format: SbomFormatSpdx},
+		},
+		{name: "other bom format", document: `{"bomFormat": "Other", "components": []}`, wantErr: true},
+		{name: "not json", document: `SPDXVersion: SPDX-2.3`, wantErr: true},
+		{name: "empty object", document: `{}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSbom([]byte(tt.document))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSbom() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSbom() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
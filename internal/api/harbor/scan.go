@@ -0,0 +1,73 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+const (
+	ScanStatusPending   = "Pending"
+	ScanStatusRunning   = "Running"
+	ScanStatusScheduled = "Scheduled"
+	ScanStatusSuccess   = "Success"
+	ScanStatusError     = "Error"
+	ScanStatusStopped   = "Stopped"
+)
+
+// InProgress reports whether the scan has not finished yet.
+func (s ScanOverview) InProgress() bool {
+	switch s.ScanStatus {
+	case ScanStatusPending, ScanStatusRunning, ScanStatusScheduled:
+		return true
+	}
+	return false
+}
+
+// ScanArtifact asks harbor to scan the artifact for vulnerabilities. The scan
+// runs asynchronously, use FetchScanOverview to follow its progress.
+func (h harborApiClient) ScanArtifact(ctx context.Context, project string, repository string, reference string) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s/scan", h.baseUrl, project, repository, reference)
+	slog.Debug(fmt.Sprintf("Scanning artifact. URL: %s", url))
+	req, err := h.newRequest(ctx, "POST", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Scan of artifact %s requested", reference))
+
+	return nil
+}
+
+// StopScanArtifact stops the scan running for the artifact.
+func (h harborApiClient) StopScanArtifact(ctx context.Context, project string, repository string, reference string) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s/scan/stop", h.baseUrl, project, repository, reference)
+	slog.Debug(fmt.Sprintf("Stopping artifact scan. URL: %s", url))
+	req, err := h.newRequest(ctx, "POST", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Scan of artifact %s stopped", reference))
+
+	return nil
+}
+
+// FetchScanOverview returns the current scan status of the artifact, or nil
+// when it was never scanned.
+func (h harborApiClient) FetchScanOverview(ctx context.Context, project string, repository string, reference string) (*ScanOverview, error) {
+	artifact, err := h.FetchArtifact(ctx, project, repository, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	return artifact.Overview(), nil
+}
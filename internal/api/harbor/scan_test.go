@@ -0,0 +1,63 @@
+package harbor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestScanOverviewInProgress(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{status: ScanStatusPending, want: true},
+		{status: ScanStatusRunning, want: true},
+		{status: ScanStatusScheduled, want: true},
+		{status: ScanStatusSuccess, want: false},
+		{status: ScanStatusError, want: false},
+		{status: ScanStatusStopped, want: false},
+		{status: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := (ScanOverview{ScanStatus: tt.status}).InProgress(); got != tt.want {
+			t.Errorf("InProgress() with status %q = %t, want %t", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestScanArtifact(t *testing.T) {
+	tests := []struct {
+		name     string
+		stop     bool
+		wantPath string
+	}{
+		{name: "scan", wantPath: "/api/v2.0/projects/library/repositories/app/artifacts/sha256:a/scan"},
+		{name: "stop", stop: true, wantPath: "/api/v2.0/projects/library/repositories/app/artifacts/sha256:a/scan/stop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != tt.wantPath {
+					t.Errorf("requested %s %s, want POST %s", r.Method, r.URL.Path, tt.wantPath)
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			h := harborApiClient{client: server.Client(), baseUrl: server.URL}
+			var err error
+			if tt.stop {
+				err = h.StopScanArtifact(context.Background(), "library", "app", "sha256:a")
+			} else {
+				err = h.ScanArtifact(context.Background(), "library", "app", "sha256:a")
+			}
+			if err != nil {
+				t.Errorf("error = %v", err)
+			}
+		})
+	}
+}
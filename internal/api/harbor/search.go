@@ -0,0 +1,41 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+)
+
+type SearchRepository struct {
+	ProjectId      int    `json:"project_id"`
+	ProjectName    string `json:"project_name"`
+	ProjectPublic  bool   `json:"project_public"`
+	RepositoryName string `json:"repository_name"`
+	PullCount      int    `json:"pull_count"`
+	ArtifactCount  int    `json:"artifact_count"`
+}
+
+type SearchResult struct {
+	Project    []ProjectsResult   `json:"project"`
+	Repository []SearchRepository `json:"repository"`
+}
+
+// Search finds the projects and repositories whose name contains query.
+func (h harborApiClient) Search(ctx context.Context, query string) (*SearchResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/search?q=%s", h.baseUrl, url.QueryEscape(query))
+	slog.Debug(fmt.Sprintf("Searching. URL: %s", url))
+	req, err := h.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var searchResp SearchResult
+	if _, err := h.do(req, &searchResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Search done", "data", fmt.Sprintf("%+v", searchResp))
+
+	return &searchResp, nil
+}
@@ -0,0 +1,108 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type ComponentHealth struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type HealthResult struct {
+	Status     string            `json:"status"`
+	Components []ComponentHealth `json:"components"`
+}
+
+type SystemInfoResult struct {
+	AuthMode                    string `json:"auth_mode"`
+	ExternalUrl                 string `json:"external_url"`
+	HarborVersion               string `json:"harbor_version"`
+	ProjectCreationRestriction  string `json:"project_creation_restriction"`
+	ReadOnly                    bool   `json:"read_only"`
+	RegistryStorageProviderName string `json:"registry_storage_provider_name"`
+	RegistryUrl                 string `json:"registry_url"`
+	SelfRegistration            bool   `json:"self_registration"`
+	NotificationEnable          bool   `json:"notification_enable"`
+	OidcProviderName            string `json:"oidc_provider_name"`
+}
+
+type Storage struct {
+	Total int64 `json:"total"`
+	Free  int64 `json:"free"`
+}
+
+type SystemVolumesResult struct {
+	Storage []Storage `json:"storage"`
+}
+
+type StatisticsResult struct {
+	PrivateProjectCount     int   `json:"private_project_count"`
+	PrivateRepoCount        int   `json:"private_repo_count"`
+	PublicProjectCount      int   `json:"public_project_count"`
+	PublicRepoCount         int   `json:"public_repo_count"`
+	TotalProjectCount       int   `json:"total_project_count"`
+	TotalRepoCount          int   `json:"total_repo_count"`
+	TotalStorageConsumption int64 `json:"total_storage_consumption"`
+}
+
+func (h harborApiClient) FetchHealth(ctx context.Context) (*HealthResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/health", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching health. URL: %s", url))
+
+	var healthResp HealthResult
+	if err := h.get(ctx, url, &healthResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Health fetched", "data", fmt.Sprintf("%+v", healthResp))
+
+	return &healthResp, nil
+}
+
+func (h harborApiClient) FetchSystemInfo(ctx context.Context) (*SystemInfoResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/systeminfo", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching system info. URL: %s", url))
+
+	var infoResp SystemInfoResult
+	if err := h.get(ctx, url, &infoResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("System info fetched", "data", fmt.Sprintf("%+v", infoResp))
+
+	return &infoResp, nil
+}
+
+// FetchSystemVolumes returns the size of the registry storage. Only system
+// administrators are allowed to read it.
+func (h harborApiClient) FetchSystemVolumes(ctx context.Context) (*SystemVolumesResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/systeminfo/volumes", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching system volumes. URL: %s", url))
+
+	var volumesResp SystemVolumesResult
+	if err := h.get(ctx, url, &volumesResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("System volumes fetched", "data", fmt.Sprintf("%+v", volumesResp))
+
+	return &volumesResp, nil
+}
+
+func (h harborApiClient) FetchStatistics(ctx context.Context) (*StatisticsResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/statistics", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching statistics. URL: %s", url))
+
+	var statisticsResp StatisticsResult
+	if err := h.get(ctx, url, &statisticsResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Statistics fetched", "data", fmt.Sprintf("%+v", statisticsResp))
+
+	return &statisticsResp, nil
+}
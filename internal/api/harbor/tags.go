@@ -0,0 +1,83 @@
+package harbor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+type TagRequestBody struct {
+	Name string `json:"name"`
+}
+
+// CreateTag adds tag to the artifact identified by reference, a digest or
+// another tag of the same artifact.
+func (h harborApiClient) CreateTag(ctx context.Context, project string, repository string, reference string, tag string) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s/tags", h.baseUrl, project, repository, reference)
+	slog.Debug(fmt.Sprintf("Creating tag %s. URL: %s", tag, url))
+	req, err := h.newRequest(ctx, "POST", url, TagRequestBody{Name: tag})
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Tag %s added to artifact %s", tag, reference))
+
+	return nil
+}
+
+// DeleteTag removes tag from the artifact identified by reference without
+// deleting the artifact itself.
+func (h harborApiClient) DeleteTag(ctx context.Context, project string, repository string, reference string, tag string) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s/tags/%s", h.baseUrl, project, repository, reference, tag)
+	slog.Debug(fmt.Sprintf("Deleting tag %s. URL: %s", tag, url))
+	req, err := h.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Tag %s removed from artifact %s", tag, reference))
+
+	return nil
+}
+
+// MoveTag points tag at the artifact identified by reference, removing it
+// from the artifact currently holding it. Tag names are unique within a
+// repository, so the tag is released first and restored on its previous
+// artifact when the new one cannot take it.
+func (h harborApiClient) MoveTag(ctx context.Context, project string, repository string, reference string, tag string) error {
+	current, err := h.FetchArtifact(ctx, project, repository, tag)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to find artifact tagged %s: %w", tag, err)
+	}
+
+	if current != nil {
+		if current.Digest == reference {
+			return nil
+		}
+
+		if err := h.DeleteTag(ctx, project, repository, current.Digest, tag); err != nil {
+			return err
+		}
+	}
+
+	if err := h.CreateTag(ctx, project, repository, reference, tag); err != nil {
+		if current != nil {
+			// Restore even if ctx was cancelled, the tag would be lost otherwise
+			if restoreErr := h.CreateTag(context.WithoutCancel(ctx), project, repository, current.Digest, tag); restoreErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to restore tag %s on %s: %w", tag, current.Digest, restoreErr))
+			}
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package harbor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// tagRegistry fakes the tag endpoints of a repository, tags maps each tag
+// to the digest it points at.
+type tagRegistry struct {
+	tags map[string]string
+	// refuse makes tagging this digest fail
+	refuse string
+}
+
+func (r *tagRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	path := strings.TrimPrefix(req.URL.Path, "/api/v2.0/projects/library/repositories/app/artifacts/")
+	parts := strings.Split(path, "/")
+
+	switch {
+	case req.Method == "GET" && len(parts) == 1:
+		digest, ok := r.tags[parts[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"digest": %q}`, digest)
+	case req.Method == "DELETE" && len(parts) == 3 && parts[1] == "tags":
+		delete(r.tags, parts[2])
+	case req.Method == "POST" && len(parts) == 2 && parts[1] == "tags":
+		var body TagRequestBody
+		json.NewDecoder(req.Body).Decode(&body)
+		if parts[0] == r.refuse {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if _, ok := r.tags[body.Name]; ok {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		r.tags[body.Name] = parts[0]
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestMoveTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    map[string]string
+		refuse  string
+		want    string
+		wantErr bool
+	}{
+		{name: "moved", tags: map[string]string{"latest": "sha256:old"}, want: "sha256:new"},
+		{name: "new tag", tags: map[string]string{}, want: "sha256:new"},
+		{name: "already there", tags: map[string]string{"latest": "sha256:new"}, want: "sha256:new"},
+		{name: "restored on failure", tags: map[string]string{"latest": "sha256:old"}, refuse: "sha256:new", want: "sha256:old", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := &tagRegistry{tags: tt.tags, refuse: tt.refuse}
+			server := httptest.NewServer(registry)
+			defer server.Close()
+
+			h := harborApiClient{client: server.Client(), baseUrl: server.URL}
+			err := h.MoveTag(context.Background(), "library", "app", "sha256:new", "latest")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MoveTag() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got := registry.tags["latest"]; got != tt.want {
+				t.Errorf("latest points at %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type CurrentUserResult struct {
+	UserId          int    `json:"user_id"`
+	Username        string `json:"username"`
+	Email           string `json:"email"`
+	Realname        string `json:"realname"`
+	SysadminFlag    bool   `json:"sysadmin_flag"`
+	AdminRoleInAuth bool   `json:"admin_role_in_auth"`
+}
+
+// FetchCurrentUser returns the user the client is authenticated as.
+func (h harborApiClient) FetchCurrentUser(ctx context.Context) (*CurrentUserResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/users/current", h.baseUrl)
+	slog.Debug(fmt.Sprintf("Fetching current user. URL: %s", url))
+
+	var userResp CurrentUserResult
+	if err := h.get(ctx, url, &userResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Current user fetched", "username", userResp.Username, "sysadmin", userResp.SysadminFlag)
+
+	return &userResp, nil
+}
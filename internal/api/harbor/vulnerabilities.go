@@ -0,0 +1,94 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+const (
+	SeverityCritical = "Critical"
+	SeverityHigh     = "High"
+	SeverityMedium   = "Medium"
+	SeverityLow      = "Low"
+	SeverityUnknown  = "Unknown"
+	SeverityNone     = "None"
+)
+
+type Scanner struct {
+	Name    string `json:"name"`
+	Vendor  string `json:"vendor"`
+	Version string `json:"version"`
+}
+
+type VulnerabilitySummary struct {
+	Total   int            `json:"total"`
+	Fixable int            `json:"fixable"`
+	Summary map[string]int `json:"summary"`
+}
+
+type ScanOverview struct {
+	ReportId        string               `json:"report_id"`
+	ScanStatus      string               `json:"scan_status"`
+	Severity        string               `json:"severity"`
+	Duration        int                  `json:"duration"`
+	Summary         VulnerabilitySummary `json:"summary"`
+	StartTime       string               `json:"start_time"`
+	EndTime         string               `json:"end_time"`
+	Scanner         Scanner              `json:"scanner"`
+	CompletePercent int                  `json:"complete_percent"`
+}
+
+type Vulnerability struct {
+	Id          string   `json:"id"`
+	Package     string   `json:"package"`
+	Version     string   `json:"version"`
+	FixVersion  string   `json:"fix_version"`
+	Severity    string   `json:"severity"`
+	Description string   `json:"description"`
+	Links       []string `json:"links"`
+}
+
+type VulnerabilityReport struct {
+	GeneratedAt     string          `json:"generated_at"`
+	Scanner         Scanner         `json:"scanner"`
+	Severity        string          `json:"severity"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// Overview returns the vulnerability scan overview of the artifact, or nil
+// when it was never scanned. Harbor keys the overview by report mime type.
+func (a ArtifactsResult) Overview() *ScanOverview {
+	for mimeType, overview := range a.ScanOverview {
+		if strings.Contains(mimeType, "vulnerability") {
+			return &overview
+		}
+	}
+	return nil
+}
+
+// FetchVulnerabilities follows the vulnerabilities addition link of an
+// artifact and returns its full report.
+func (h harborApiClient) FetchVulnerabilities(ctx context.Context, link AdditionLink) (*VulnerabilityReport, error) {
+	url := h.additionUrl(link)
+	slog.Debug(fmt.Sprintf("Fetching vulnerabilities. URL: %s", url))
+	req, err := h.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports map[string]VulnerabilityReport
+	if _, err := h.do(req, &reports); err != nil {
+		return nil, err
+	}
+
+	for mimeType, report := range reports {
+		if strings.Contains(mimeType, "vulnerability") {
+			slog.Debug("Vulnerabilities fetched", "count", len(report.Vulnerabilities))
+			return &report, nil
+		}
+	}
+
+	return &VulnerabilityReport{}, nil
+}
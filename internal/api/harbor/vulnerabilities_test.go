@@ -0,0 +1,80 @@
+package harbor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestArtifactOverview(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		wantStatus string
+	}{
+		{name: "never scanned", payload: `{"digest": "sha256:a"}`},
+		{
+			name:       "vulnerability report",
+			payload:    `{"scan_overview": {"application/vnd.security.vulnerability.report; version=1.1": {"scan_status": "Success"}}}`,
+			wantStatus: "Success",
+		},
+		{
+			name:    "other report only",
+			payload: `{"scan_overview": {"application/vnd.security.sbom.report+json; version=1.0": {"scan_status": "Success"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a ArtifactsResult
+			if err := json.Unmarshal([]byte(tt.payload), &a); err != nil {
+				t.Fatalf("decoding artifact: %v", err)
+			}
+			got := a.Overview()
+			if tt.wantStatus == "" {
+				if got != nil {
+					t.Errorf("Overview() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.ScanStatus != tt.wantStatus {
+				t.Errorf("Overview() = %+v, want status %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestFetchVulnerabilities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2.0/projects/library/repositories/app/artifacts/sha256:a/additions/vulnerabilities" {
+			t.Errorf("requested %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"application/vnd.security.vulnerability.report; version=1.1": {
+			"severity": "High",
+			"vulnerabilities": [{"id": "CVE-2024-1", "package": "openssl", "severity": "High"}]
+		}}`))
+	}))
+	defer server.Close()
+
+	h := harborApiClient{client: server.Client(), baseUrl: server.URL}
+	report, err := h.FetchVulnerabilities(context.Background(), AdditionLink{Href: "/api/v2.0/projects/library/repositories/app/artifacts/sha256:a/additions/vulnerabilities"})
+	if err != nil {
+		t.Fatalf("FetchVulnerabilities() error = %v", err)
+	}
+	if report.Severity != "High" || len(report.Vulnerabilities) != 1 || report.Vulnerabilities[0].Id != "CVE-2024-1" {
+		t.Errorf("FetchVulnerabilities() = %+v", report)
+	}
+}
+
+func TestAdditionUrl(t *testing.T) {
+	h := harborApiClient{baseUrl: "https://harbor.example.com"}
+
+	if got, want := h.additionUrl(AdditionLink{Href: "/api/v2.0/x"}), "https://harbor.example.com/api/v2.0/x"; got != want {
+		t.Errorf("additionUrl() = %q, want %q", got, want)
+	}
+	if got, want := h.additionUrl(AdditionLink{Absolute: true, Href: "https://other.example.com/x"}), "https://other.example.com/x"; got != want {
+		t.Errorf("additionUrl() = %q, want %q", got, want)
+	}
+}
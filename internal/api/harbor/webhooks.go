@@ -0,0 +1,206 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// Webhook target types.
+const (
+	WebhookTargetHttp  = "http"
+	WebhookTargetSlack = "slack"
+)
+
+type WebhookTarget struct {
+	Type           string `json:"type"`
+	Address        string `json:"address"`
+	AuthHeader     string `json:"auth_header,omitempty"`
+	SkipCertVerify bool   `json:"skip_cert_verify"`
+	PayloadFormat  string `json:"payload_format,omitempty"`
+}
+
+type WebhookPolicyResult struct {
+	Id           int             `json:"id,omitempty"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	ProjectId    int             `json:"project_id,omitempty"`
+	Targets      []WebhookTarget `json:"targets"`
+	EventTypes   []string        `json:"event_types"`
+	Creator      string          `json:"creator,omitempty"`
+	Enabled      bool            `json:"enabled"`
+	CreationTime string          `json:"creation_time,omitempty"`
+	UpdateTime   string          `json:"update_time,omitempty"`
+}
+
+type SupportedWebhookEventTypes struct {
+	EventType  []string `json:"event_type"`
+	NotifyType []string `json:"notify_type"`
+}
+
+type WebhookExecutionResult struct {
+	Id            int            `json:"id"`
+	VendorType    string         `json:"vendor_type"`
+	VendorId      int            `json:"vendor_id"`
+	Status        string         `json:"status"`
+	StatusMessage string         `json:"status_message"`
+	Trigger       string         `json:"trigger"`
+	ExtraAttrs    map[string]any `json:"extra_attrs"`
+	StartTime     string         `json:"start_time"`
+	EndTime       string         `json:"end_time"`
+}
+
+// EventType returns the event that triggered the execution.
+func (e WebhookExecutionResult) EventType() string {
+	if eventType, ok := e.ExtraAttrs["event_type"].(string); ok {
+		return eventType
+	}
+	return ""
+}
+
+type WebhookTaskResult struct {
+	Id            int    `json:"id"`
+	ExecutionId   int    `json:"execution_id"`
+	Status        string `json:"status"`
+	StatusMessage string `json:"status_message"`
+	RunCount      int    `json:"run_count"`
+	CreationTime  string `json:"creation_time"`
+	StartTime     string `json:"start_time"`
+	UpdateTime    string `json:"update_time"`
+	EndTime       string `json:"end_time"`
+}
+
+func (h harborApiClient) FetchWebhookPolicies(ctx context.Context, project string) (*[]WebhookPolicyResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/webhook/policies", h.baseUrl, project)
+	slog.Debug(fmt.Sprintf("Fetching webhook policies. URL: %s", url))
+
+	policiesResp, err := fetchAllPages[WebhookPolicyResult](ctx, h, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Targets hold credentials in their auth header, only ids are logged
+	ids := make([]int, len(policiesResp))
+	for i, p := range policiesResp {
+		ids[i] = p.Id
+	}
+	slog.Debug("Webhook policies fetched", "count", len(policiesResp), "ids", ids)
+
+	return &policiesResp, nil
+}
+
+// FetchWebhookEventTypes returns the events webhooks of project can be
+// notified of.
+func (h harborApiClient) FetchWebhookEventTypes(ctx context.Context, project string) (*SupportedWebhookEventTypes, error) {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/webhook/events", h.baseUrl, project)
+	slog.Debug(fmt.Sprintf("Fetching webhook event types. URL: %s", url))
+
+	var eventsResp SupportedWebhookEventTypes
+	if err := h.get(ctx, url, &eventsResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Webhook event types fetched", "data", fmt.Sprintf("%+v", eventsResp))
+
+	return &eventsResp, nil
+}
+
+func (h harborApiClient) CreateWebhookPolicy(ctx context.Context, project string, policy WebhookPolicyResult) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/webhook/policies", h.baseUrl, project)
+	slog.Debug(fmt.Sprintf("Creating webhook policy %s. URL: %s", policy.Name, url))
+	req, err := h.newRequest(ctx, "POST", url, policy)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Webhook policy %s created", policy.Name))
+
+	return nil
+}
+
+// UpdateWebhookPolicy replaces the webhook policy identified by policy.Id,
+// e.g. to enable or disable it.
+func (h harborApiClient) UpdateWebhookPolicy(ctx context.Context, project string, policy WebhookPolicyResult) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/webhook/policies/%d", h.baseUrl, project, policy.Id)
+	slog.Debug(fmt.Sprintf("Updating webhook policy %s. URL: %s", policy.Name, url))
+	req, err := h.newRequest(ctx, "PUT", url, policy)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Webhook policy %s updated", policy.Name))
+
+	return nil
+}
+
+func (h harborApiClient) DeleteWebhookPolicy(ctx context.Context, project string, id int) error {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/webhook/policies/%d", h.baseUrl, project, id)
+	slog.Debug(fmt.Sprintf("Deleting webhook policy. URL: %s", url))
+	req, err := h.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.do(req, nil); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Webhook policy %d deleted", id))
+
+	return nil
+}
+
+// FetchWebhookExecutions returns the latest limit executions of the webhook
+// policy identified by policyId, most recent first.
+func (h harborApiClient) FetchWebhookExecutions(ctx context.Context, project string, policyId int, limit int) (*[]WebhookExecutionResult, error) {
+	query := urlValues("sort", "-start_time")
+	query.Set("page", "1")
+	query.Set("page_size", strconv.Itoa(limit))
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/webhook/policies/%d/executions?%s", h.baseUrl, project, policyId, query.Encode())
+	slog.Debug(fmt.Sprintf("Fetching webhook executions. URL: %s", url))
+
+	var executionsResp []WebhookExecutionResult
+	if err := h.get(ctx, url, &executionsResp); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Webhook executions fetched", "data", fmt.Sprintf("%+v", executionsResp))
+
+	return &executionsResp, nil
+}
+
+func (h harborApiClient) FetchWebhookTasks(ctx context.Context, project string, policyId int, executionId int) (*[]WebhookTaskResult, error) {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/webhook/policies/%d/executions/%d/tasks", h.baseUrl, project, policyId, executionId)
+	slog.Debug(fmt.Sprintf("Fetching webhook tasks. URL: %s", url))
+
+	tasksResp, err := fetchAllPages[WebhookTaskResult](ctx, h, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Webhook tasks fetched", "data", fmt.Sprintf("%+v", tasksResp))
+
+	return &tasksResp, nil
+}
+
+// FetchWebhookTaskLog returns the plain text log of a webhook delivery.
+func (h harborApiClient) FetchWebhookTaskLog(ctx context.Context, project string, policyId int, executionId int, taskId int) (string, error) {
+	url := fmt.Sprintf("%s/api/v2.0/projects/%s/webhook/policies/%d/executions/%d/tasks/%d/log", h.baseUrl, project, policyId, executionId, taskId)
+	slog.Debug(fmt.Sprintf("Fetching webhook task log. URL: %s", url))
+
+	var log []byte
+	if err := h.get(ctx, url, &log); err != nil {
+		return "", err
+	}
+
+	return string(log), nil
+}
@@ -2,6 +2,7 @@ package portainer
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -17,7 +18,7 @@ type AuthRequestBody struct {
 	Password string `json:"password"`
 }
 
-func (p *portainerApiClient) PostAuth() error {
+func (p *portainerApiClient) PostAuth(ctx context.Context) error {
 	slog.Debug("Authenticating using portainer api")
 	url := fmt.Sprintf("%s/api/auth", p.baseUrl)
 
@@ -31,7 +32,7 @@ func (p *portainerApiClient) PostAuth() error {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
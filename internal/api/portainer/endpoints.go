@@ -1,6 +1,7 @@
 package portainer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -36,11 +37,11 @@ type EndpointsResult struct {
 	Tags             any              `json:"Tags"`
 }
 
-func (p *portainerApiClient) GetEndpoints() (*[]EndpointsResult, error) {
+func (p *portainerApiClient) GetEndpoints(ctx context.Context) (*[]EndpointsResult, error) {
 	slog.Debug("Fetching endpoints from portainer api")
 	url := fmt.Sprintf("%s/api/endpoints", p.baseUrl)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -65,7 +66,7 @@ func (p *portainerApiClient) GetEndpoints() (*[]EndpointsResult, error) {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	slog.Debug("Endpoints from portainer api", fmt.Sprintf("%+v", endpointsResp))
+	slog.Debug("Endpoints from portainer api", "data", fmt.Sprintf("%+v", endpointsResp))
 
 	return &endpointsResp, nil
 }
@@ -0,0 +1,47 @@
+package portainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+type StatusResult struct {
+	Version    string `json:"Version"`
+	InstanceId string `json:"InstanceID"`
+}
+
+// GetStatus reads the public status endpoint, it does not need PostAuth.
+func (p *portainerApiClient) GetStatus(ctx context.Context) (*StatusResult, error) {
+	slog.Debug("Fetching status from portainer api")
+	url := fmt.Sprintf("%s/api/status", p.baseUrl)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "harborw/1.0")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+	}
+
+	var statusResp StatusResult
+	if err := json.NewDecoder(resp.Body).Decode(&statusResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	slog.Debug("Status from portainer api", "data", fmt.Sprintf("%+v", statusResp))
+
+	return &statusResp, nil
+}
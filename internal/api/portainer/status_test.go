@@ -0,0 +1,47 @@
+package portainer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantVersion string
+		wantErr     bool
+	}{
+		{name: "reachable", status: http.StatusOK, body: `{"Version": "2.19.4", "InstanceID": "abc"}`, wantVersion: "2.19.4"},
+		{name: "failing", status: http.StatusInternalServerError, wantErr: true},
+		{name: "not json", status: http.StatusOK, body: "<html>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/status" {
+					t.Errorf("requested %s, want /api/status", r.URL.Path)
+				}
+				if r.Header.Get("Authorization") != "" {
+					t.Error("status probe sent credentials")
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			p := portainerApiClient{client: server.Client(), baseUrl: server.URL}
+			got, err := p.GetStatus(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetStatus() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err == nil && got.Version != tt.wantVersion {
+				t.Errorf("GetStatus() version = %q, want %q", got.Version, tt.wantVersion)
+			}
+		})
+	}
+}
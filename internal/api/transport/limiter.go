@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter bounds how many requests run at the same time and how often new
+// ones may start. A nil *Limiter does not limit anything.
+type Limiter struct {
+	slots    chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewLimiter allows up to concurrency requests in flight and at most
+// perSecond request starts per second. Zero disables the matching limit.
+func NewLimiter(concurrency int, perSecond float64) *Limiter {
+	l := &Limiter{}
+	if concurrency > 0 {
+		l.slots = make(chan struct{}, concurrency)
+	}
+	if perSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return l
+}
+
+// Acquire blocks until a request may start or ctx is done. Every successful
+// Acquire must be followed by a Release.
+func (l *Limiter) Acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := sleep(ctx, l.reserve()); err != nil {
+		l.Release()
+		return err
+	}
+
+	return nil
+}
+
+func (l *Limiter) Release() {
+	if l == nil || l.slots == nil {
+		return
+	}
+	<-l.slots
+}
+
+// reserve books the next start time and returns how long to wait for it.
+func (l *Limiter) reserve() time.Duration {
+	if l.interval == 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+
+	return wait
+}
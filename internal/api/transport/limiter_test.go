@@ -0,0 +1,100 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiterConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		requests    int
+		// want is the highest number of requests in flight allowed
+		want int32
+	}{
+		{name: "one at a time", concurrency: 1, requests: 8, want: 1},
+		{name: "bounded", concurrency: 3, requests: 12, want: 3},
+		{name: "unlimited", concurrency: 0, requests: 5, want: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter(tt.concurrency, 0)
+			var inFlight, highest atomic.Int32
+			var wg sync.WaitGroup
+			for range tt.requests {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := l.Acquire(context.Background()); err != nil {
+						t.Error(err)
+						return
+					}
+					defer l.Release()
+
+					n := inFlight.Add(1)
+					for {
+						h := highest.Load()
+						if n <= h || highest.CompareAndSwap(h, n) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					inFlight.Add(-1)
+				}()
+			}
+			wg.Wait()
+
+			if got := highest.Load(); got > tt.want {
+				t.Errorf("%d requests in flight, want at most %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterRate(t *testing.T) {
+	l := NewLimiter(0, 100)
+	start := time.Now()
+	for range 5 {
+		if err := l.Acquire(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		l.Release()
+	}
+
+	// The first start is immediate, the 4 others wait 10ms each
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("5 starts took %s, want at least 40ms at 100 per second", elapsed)
+	}
+}
+
+func TestLimiterAcquireCancelled(t *testing.T) {
+	l := NewLimiter(1, 0)
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire() on a full limiter error = %v, want context.DeadlineExceeded", err)
+	}
+
+	l.Release()
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Errorf("Acquire() after Release() error = %v", err)
+	}
+}
+
+func TestNilLimiter(t *testing.T) {
+	var l *Limiter
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Errorf("Acquire() on a nil limiter error = %v", err)
+	}
+	l.Release()
+}
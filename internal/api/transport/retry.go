@@ -0,0 +1,112 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, the first one included.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// Statuses answered before the request reached the backend, always safe to retry.
+var rejectedStatuses = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+
+// Statuses that may hide a processed request, only retried for idempotent methods.
+var gatewayStatuses = []int{http.StatusBadGateway, http.StatusGatewayTimeout}
+
+var idempotentMethods = []string{"GET", "HEAD", "OPTIONS", "PUT", "DELETE"}
+
+func (p RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	idempotent := slices.Contains(idempotentMethods, req.Method)
+	if err != nil {
+		return idempotent
+	}
+
+	if slices.Contains(rejectedStatuses, resp.StatusCode) {
+		return true
+	}
+
+	return idempotent && slices.Contains(gatewayStatuses, resp.StatusCode)
+}
+
+// backoff returns how long to wait before the given retry attempt, honoring
+// the Retry-After header of resp when the server sent one.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(delay, p.MaxDelay)
+		}
+	}
+
+	// Exponential backoff with full jitter
+	ceiling := min(p.BaseDelay<<attempt, p.MaxDelay)
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling)
+}
+
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// discard drains and closes the body of a response that is going to be
+// retried so its connection can be reused.
+func discard(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	slog.Debug("Discarded response before retrying", "status", resp.StatusCode)
+}
@@ -0,0 +1,104 @@
+package transport
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// Transport is an http.RoundTripper retrying failed requests according to
+// Policy while keeping every attempt within the bounds of Limiter.
+type Transport struct {
+	Base    http.RoundTripper
+	Policy  RetryPolicy
+	Limiter *Limiter
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		if err := t.Limiter.Acquire(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := base.RoundTrip(attemptReq)
+		t.Limiter.Release()
+
+		if attempt >= t.Policy.MaxAttempts || !t.Policy.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.Policy.backoff(attempt-1, resp)
+		slog.Debug(fmt.Sprintf("Retrying %s %s in %s", req.Method, req.URL, delay), "attempt", attempt, "err", err)
+		discard(resp)
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// NewHttpClient returns a client whose requests are retried with policy and
+// limited by limiter. Share the client to share the limits.
+func NewHttpClient(policy RetryPolicy, limiter *Limiter) *http.Client {
+	return &http.Client{
+		Transport: &Transport{
+			Policy:  policy,
+			Limiter: limiter,
+		},
+	}
+}
+
+// NewHttpClientFromEnv configures NewHttpClient from the optional
+// HARBORW_MAX_RETRIES, HARBORW_MAX_CONCURRENCY and HARBORW_RATE_LIMIT
+// environment variables.
+func NewHttpClientFromEnv() *http.Client {
+	policy := DefaultRetryPolicy()
+	if retries, ok := intFromEnv("HARBORW_MAX_RETRIES"); ok {
+		policy.MaxAttempts = retries + 1
+	}
+
+	concurrency := 4
+	if value, ok := intFromEnv("HARBORW_MAX_CONCURRENCY"); ok {
+		concurrency = value
+	}
+
+	perSecond := 10.0
+	if value, ok := intFromEnv("HARBORW_RATE_LIMIT"); ok {
+		perSecond = float64(value)
+	}
+
+	slog.Debug("Creating http client", "policy", fmt.Sprintf("%+v", policy), "concurrency", concurrency, "rate", perSecond)
+
+	return NewHttpClient(policy, NewLimiter(concurrency, perSecond))
+}
+
+func intFromEnv(name string) (int, bool) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		slog.Warn(fmt.Sprintf("Ignoring invalid %s", name), "value", value)
+		return 0, false
+	}
+
+	return n, true
+}
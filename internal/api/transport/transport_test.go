@@ -0,0 +1,153 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripFunc answers requests without any network.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+var errConnection = errors.New("connection reset")
+
+func TestTransportRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		// answers are the statuses of each attempt, 0 fails with errConnection
+		answers      []int
+		maxAttempts  int
+		wantStatus   int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{name: "success", method: "GET", answers: []int{200}, maxAttempts: 4, wantStatus: 200, wantAttempts: 1},
+		{name: "client error is final", method: "GET", answers: []int{404, 200}, maxAttempts: 4, wantStatus: 404, wantAttempts: 1},
+		{name: "unavailable retried", method: "GET", answers: []int{503, 503, 200}, maxAttempts: 4, wantStatus: 200, wantAttempts: 3},
+		{name: "attempts exhausted", method: "GET", answers: []int{503, 503, 503, 200}, maxAttempts: 3, wantStatus: 503, wantAttempts: 3},
+		{name: "too many requests retried for post", method: "POST", body: "{}", answers: []int{429, 201}, maxAttempts: 4, wantStatus: 201, wantAttempts: 2},
+		{name: "bad gateway retried for delete", method: "DELETE", answers: []int{502, 200}, maxAttempts: 4, wantStatus: 200, wantAttempts: 2},
+		{name: "bad gateway not retried for post", method: "POST", body: "{}", answers: []int{502, 201}, maxAttempts: 4, wantStatus: 502, wantAttempts: 1},
+		{name: "connection error retried for get", method: "GET", answers: []int{0, 200}, maxAttempts: 4, wantStatus: 200, wantAttempts: 2},
+		{name: "connection error not retried for post", method: "POST", body: "{}", answers: []int{0, 201}, maxAttempts: 4, wantErr: true, wantAttempts: 1},
+		{name: "single attempt", method: "GET", answers: []int{503, 200}, maxAttempts: 1, wantStatus: 503, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				status := tt.answers[attempts]
+				attempts++
+
+				if req.Body != nil {
+					body, _ := io.ReadAll(req.Body)
+					if string(body) != tt.body {
+						t.Errorf("attempt %d sent body %q, want %q", attempts, body, tt.body)
+					}
+				}
+				if status == 0 {
+					return nil, errConnection
+				}
+				return &http.Response{
+					StatusCode: status,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			})
+
+			transport := &Transport{
+				Base:    base,
+				Policy:  RetryPolicy{MaxAttempts: tt.maxAttempts, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+				Limiter: NewLimiter(1, 0),
+			}
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequest(tt.method, "http://harbor.example.com/api/v2.0/projects", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RoundTrip() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err == nil && resp.StatusCode != tt.wantStatus {
+				t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("RoundTrip() made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestTransportStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	transport := &Transport{
+		Base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			cancel()
+			return &http.Response{StatusCode: 503, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}),
+		Policy: RetryPolicy{MaxAttempts: 4, BaseDelay: time.Hour, MaxDelay: time.Hour},
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", "http://harbor.example.com", nil)
+	if _, err := transport.RoundTrip(req); err != nil && !errors.Is(err, context.Canceled) {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("RoundTrip() made %d attempts after being cancelled, want 1", attempts)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{value: "", wantOk: false},
+		{value: "3", want: 3 * time.Second, wantOk: true},
+		{value: "soon", wantOk: false},
+		{value: "Mon, 01 Jan 2001 00:00:00 GMT", want: 0, wantOk: true},
+	}
+
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.value)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("retryAfter(%q) = %s, %t, want %s, %t", tt.value, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 4, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt := range 6 {
+		ceiling := min(policy.BaseDelay<<attempt, policy.MaxDelay)
+		if got := policy.backoff(attempt, nil); got < 0 || got >= ceiling {
+			t.Errorf("backoff(%d) = %s, want within [0, %s)", attempt, got, ceiling)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"30"}}}
+	if got := policy.backoff(0, resp); got != policy.MaxDelay {
+		t.Errorf("backoff() with Retry-After = %s, want it capped to %s", got, policy.MaxDelay)
+	}
+}
@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+// accessoriesSummary flags whether an artifact is signed and has an SBOM.
+func accessoriesSummary(a Artifact) string {
+	flags := []string{}
+	if a.Signed {
+		flags = append(flags, "sig")
+	}
+	if a.Sbom {
+		flags = append(flags, "sbom")
+	}
+	return strings.Join(flags, " ")
+}
+
+type Accessory struct {
+	Type         string
+	Hash         string
+	Size         float64
+	CreationTime string
+}
+
+func (a Accessory) ToRow() []string {
+	return []string{
+		a.Type,
+		a.Hash,
+		fmt.Sprintf("%.2f KiB", a.Size/1024),
+		a.CreationTime,
+	}
+}
+
+type AccessoriesState struct {
+	table    table.Model
+	data     []Accessory
+	artifact Artifact
+	prompt   PromptState
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+type accessoriesLoadedMsg struct {
+	accessories []Accessory
+	err         error
+}
+
+type accessoryDeleteMsg struct {
+	accessory Accessory
+	err       error
+}
+
+func fetchAccessories(ctx context.Context, artifact Artifact) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			slog.Error("Error creating harbor client", "err", err)
+			return accessoriesLoadedMsg{err: err}
+		}
+
+		r, err := harborClient.FetchAccessories(ctx, artifact.Project, artifact.Repository, artifact.Hash)
+		if err != nil {
+			slog.Error("Error fetching accessories", "err", err)
+			return accessoriesLoadedMsg{err: fmt.Errorf("failed to fetch accessories: %w", err)}
+		}
+
+		accessories := make([]Accessory, len(*r))
+		for i, a := range *r {
+			accessories[i] = Accessory{
+				Type:         a.Type,
+				Hash:         a.Digest,
+				Size:         float64(a.Size),
+				CreationTime: a.CreationTime,
+			}
+		}
+
+		return accessoriesLoadedMsg{accessories: accessories}
+	}
+}
+
+// deleteAccessory deletes a single accessory, leaving its subject untouched.
+func deleteAccessory(ctx context.Context, subject Artifact, accessory Accessory) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return accessoryDeleteMsg{accessory, err}
+		}
+
+		err = harborClient.DeleteArtifact(ctx, subject.Project, subject.Repository, accessory.Hash)
+		return accessoryDeleteMsg{accessory, err}
+	}
+}
+
+func (m model) accessoriesView() string {
+	state := m.state.accessories
+	title := fmt.Sprintf("Accessories of %s (%s)", state.artifact.Name, state.artifact.Hash)
+
+	return withPrompt(lipgloss.JoinVertical(lipgloss.Left, title, state.table.View()), state.prompt)
+}
+
+func (m model) accessoriesUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.state.accessories.prompt.handles(msg) {
+		action := m.state.accessories.prompt.action
+		prompt, value, submitted, cmd := m.state.accessories.prompt.update(msg)
+		m.state.accessories.prompt = prompt
+		if submitted && action == deleteAccessoryPromptAction && value == "yes" && len(m.state.accessories.data) > 0 {
+			state := m.state.accessories
+			return m, deleteAccessory(state.ctx, state.artifact, state.data[state.table.Cursor()])
+		}
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case accessoriesLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.accessories = newEmptyAccessoriesState(m.state.accessories, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		m.state.accessories = newAccessoriesState(m.state.accessories, msg.accessories)
+		return m, nil
+	case accessoryDeleteMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			slog.Error("Error deleting accessory", "err", msg.err)
+			m = m.setError(fmt.Errorf("failed to delete %s accessory: %w", msg.accessory.Type, msg.err))
+			return m, nil
+		}
+
+		m = m.setInfo(fmt.Sprintf("Deleted %s accessory %s", msg.accessory.Type, msg.accessory.Hash))
+		return m, fetchAccessories(m.state.accessories.ctx, m.state.accessories.artifact)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "d":
+			// Accessories are only deleted one at a time and on purpose
+			if len(m.state.accessories.data) == 0 {
+				return m, nil
+			}
+			active := m.state.accessories.data[m.state.accessories.table.Cursor()]
+			label := fmt.Sprintf("Delete %s accessory %s? Type yes to confirm", active.Type, active.Hash)
+			m.state.accessories.prompt, cmd = newPrompt(label, "", deleteAccessoryPromptAction)
+			return m, cmd
+		case "esc", "-":
+			// Go back
+			m.state.accessories.cancel()
+			return m.backToArtifacts()
+		}
+	}
+
+	m.state.accessories.table, cmd = m.state.accessories.table.Update(msg)
+	return m, cmd
+}
+
+var ACCESSORIES_COLUMNS = []table.Column{
+	{Title: "Type", Width: 20},
+	{Title: "Digest", Width: 75},
+	{Title: "Size", Width: 12},
+	{Title: "Created", Width: 25},
+}
+
+// newEmptyAccessoriesState keeps the request context of state and shows
+// message instead of data.
+func newEmptyAccessoriesState(state AccessoriesState, message string) AccessoriesState {
+	t := table.New(
+		table.WithColumns(ACCESSORIES_COLUMNS),
+		table.WithRows([]table.Row{{message, "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []Accessory{}
+
+	return state
+}
+
+func newAccessoriesState(state AccessoriesState, accessories []Accessory) AccessoriesState {
+	if len(accessories) == 0 {
+		return newEmptyAccessoriesState(state, "No accessories")
+	}
+
+	rows := make([]table.Row, len(accessories))
+	for i, a := range accessories {
+		rows[i] = a.ToRow()
+	}
+
+	t := table.New(
+		table.WithColumns(ACCESSORIES_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(21),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = accessories
+
+	slog.Debug("New Accessories state created.")
+
+	return state
+}
+
+// NewAccessoriesState returns a loading accessories state for artifact
+// along with the command that fetches them.
+func (m model) NewAccessoriesState(artifact Artifact) (AccessoriesState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := AccessoriesState{
+		artifact: artifact,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	return newEmptyAccessoriesState(state, "Loading..."), fetchAccessories(ctx, artifact)
+}
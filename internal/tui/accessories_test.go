@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestDeleteArtifactsWithAccessories(t *testing.T) {
+	tests := []struct {
+		name        string
+		accessories []harbor.Accessory
+		wantPrompt  bool
+	}{
+		{name: "no accessories", wantPrompt: false},
+		{name: "signed", accessories: []harbor.Accessory{{Type: harbor.AccessoryTypeCosign}}, wantPrompt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{}
+			artifacts := []Artifact{{Hash: "sha256:a", Selected: true, Accessories: tt.accessories}}
+			m.state.artifacts = newArtifactsState(ArtifactsState{}, artifacts)
+
+			m, cmd := m.artifactsUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+			if got := m.state.artifacts.prompt.active(); got != tt.wantPrompt {
+				t.Fatalf("confirmation asked = %t, want %t", got, tt.wantPrompt)
+			}
+			if !tt.wantPrompt {
+				if cmd == nil {
+					t.Error("artifacts without accessories were not deleted")
+				}
+				return
+			}
+
+			// Anything but yes keeps the artifact
+			for _, r := range "no" {
+				m, _ = m.artifactsUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			}
+			m, cmd = m.artifactsUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+			if cmd != nil {
+				t.Error("artifacts were deleted without confirmation")
+			}
+
+			m, _ = m.artifactsUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+			for _, r := range "yes" {
+				m, _ = m.artifactsUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			}
+			if _, cmd = m.artifactsUpdate(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+				t.Error("artifacts were not deleted once confirmed")
+			}
+		})
+	}
+}
@@ -1,13 +1,19 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/mathiasdonoso/harborw/internal/api/harbor"
 	"github.com/mathiasdonoso/harborw/internal/api/portainer"
 )
@@ -17,10 +23,23 @@ type Artifact struct {
 	Project    string
 	Repository string
 	Name       string
-	Hash       string
-	Size       float64
-	PullTime   string
-	PushTime   string
+	Tags       []string
+	Labels     []harbor.Label
+	// Parent is the digest of the index listing this artifact, if any
+	Parent   string
+	Platform string
+	Signed   bool
+	Sbom     bool
+	// Accessories are deleted along with the artifact
+	Accessories []harbor.Accessory
+	Hash        string
+	MediaType   string
+	Attributes  harbor.ExtraAttrs
+	Size        float64
+	PullTime    string
+	PushTime    string
+	Scan        *harbor.ScanOverview
+	Additions   harbor.AdditionLinks
 }
 
 func (a Artifact) ToRow() []string {
@@ -32,72 +51,111 @@ func (a Artifact) ToRow() []string {
 
 	size := float64(a.Size) / 1024 / 1024
 
+	tags := strings.Join(a.Tags, ", ")
+	if a.Parent != "" {
+		// Platforms of an index can only be deleted along with it
+		checked = ""
+		tags = "└ " + a.Platform
+	}
+
 	return []string{
 		checked,
-		a.Name,
+		tags,
 		a.Hash,
-		"",
+		strings.Join(labelNames(a.Labels), ", "),
+		accessoriesSummary(a),
+		vulnerabilitiesSummary(a.Scan),
 		fmt.Sprintf("%.2f MiB", size),
 		a.PullTime,
 		a.PushTime,
 	}
 }
 
-type DeletionProcess struct {
-	artifacts Artifact
-	status    string
-	deleted   bool
-	err       error
+type ArtifactsState struct {
+	table      table.Model
+	data       []Artifact
+	project    string
+	repository string
+	filter     string
+	labels     []harbor.Label
+	prompt     PromptState
+	pollId     int
+	copy       copyProgress
+	ctx        context.Context
+	cancel     context.CancelFunc
 }
 
-type ArtifactsState struct {
-	table table.Model
-	data  []Artifact
+type artifactsLoadedMsg struct {
+	project    string
+	repository string
+	artifacts  []Artifact
+	err        error
 }
 
-type processDeleteArtifactMsg struct {
-	Artifact  Artifact
-	canDelete bool
+// FindContainers returns the containers of every portainer endpoint running
+// the image with the given digest.
+func FindContainers(ctx context.Context, hash string) ([]portainer.ContainersResult, error) {
+	found, err := FindContainersOfImages(ctx, []string{hash})
+	if err != nil {
+		return nil, err
+	}
+
+	return found[hash], nil
 }
 
-func ImageIsInUse(hash string) (bool, error) {
-	portainerClient, err := portainer.NewPortainerApiClient(http.DefaultClient)
+// FindContainersOfImages returns, by digest, the containers of every
+// portainer endpoint running one of the images with the given digests.
+func FindContainersOfImages(ctx context.Context, hashes []string) (map[string][]portainer.ContainersResult, error) {
+	portainerClient, err := portainer.NewPortainerApiClient(httpClient)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	if err := portainerClient.PostAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	wanted := map[string]bool{}
+	for _, hash := range hashes {
+		wanted[hash] = true
 	}
 
-	slog.Debug(fmt.Sprintf("Searching for usage for image with hash: %s", hash))
-	endpoints, err := portainerClient.GetEndpoints()
+	slog.Debug(fmt.Sprintf("Searching for usage of %d images", len(hashes)))
+	endpoints, err := portainerClient.GetEndpoints(ctx)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	for i, e := range *endpoints {
-		fmt.Printf("i: %d\n", i)
-		slog.Debug(fmt.Sprintf("Searching for usage of image with hash %s inside endpoint %s", hash, e.Name))
+	found := map[string][]portainer.ContainersResult{}
+	for _, e := range *endpoints {
+		slog.Debug(fmt.Sprintf("Searching for usage of images inside endpoint %s", e.Name))
 
-		// containerInfo, err := portainerClient.GetContainersJson(e.Id)
-		containerInfo, err := portainerClient.GetContainersJson(1009)
+		containerInfo, err := portainerClient.GetContainersJson(ctx, e.Id)
 		if err != nil {
-			return false, err
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// An unreachable endpoint must not hide the containers of the others
+			slog.Error("Error listing containers of endpoint", "endpoint", e.Name, "err", err)
+			continue
 		}
 
 		for _, c := range *containerInfo {
-			slog.Debug(fmt.Sprintf("Searching for usage of image with hash: %s inside container: %s", hash, c.Image))
+			slog.Debug(fmt.Sprintf("Searching for usage of images inside container: %s", c.Image))
 
 			// "Image": "hub.fif.tech/omnichannel/privatesite:bf-co-executive-eta@sha256:b4e2f5ad6ce67c3033317119a1044044642425b2e7c9619372eab6ae226c5e75",
 			imageSections := strings.Split(c.Image, "@")
 			if len(imageSections) == 2 {
 				imageHash := imageSections[1]
 
-				if hash == imageHash {
-					return true, nil
+				if wanted[imageHash] {
+					found[imageHash] = append(found[imageHash], c)
 				}
 			}
 		}
 	}
 
-	return false, nil
+	return found, nil
 }
 
 type ArtifactDeleteMsg struct {
@@ -105,21 +163,19 @@ type ArtifactDeleteMsg struct {
 	err      error
 }
 
-// TODO: Add description code bc of lsp
-func deleteArtifact(artifact Artifact) tea.Cmd {
-	var err error
-	harborClient, err := harbor.NewHarborApiClient(http.DefaultClient)
-	if err != nil {
-		return func() tea.Msg {
+// deleteArtifact deletes the artifact digest, with every tag pointing at it,
+// and reports the result as an ArtifactDeleteMsg.
+func deleteArtifact(ctx context.Context, artifact Artifact) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
 			return ArtifactDeleteMsg{
 				artifact,
 				err,
 			}
 		}
-	}
 
-	err = harborClient.DeleteArtifact(artifact.Project, artifact.Repository, artifact.Hash)
-	return func() tea.Msg {
+		err = harborClient.DeleteArtifact(ctx, artifact.Project, artifact.Repository, artifact.Hash)
 		return ArtifactDeleteMsg{
 			artifact,
 			err,
@@ -127,27 +183,170 @@ func deleteArtifact(artifact Artifact) tea.Cmd {
 	}
 }
 
-func processDeleteArtifact(artifact Artifact) tea.Cmd {
-	canDelete := false
+func deleteArtifacts(ctx context.Context, artifacts []Artifact) tea.Cmd {
+	cmds := []tea.Cmd{}
 
-	result, err := ImageIsInUse(artifact.Hash)
-	if err != nil {
-		fmt.Printf("ERROR: %s\n", err.Error())
+	for _, a := range artifacts {
+		cmds = append(cmds, deleteArtifact(ctx, a))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func newArtifact(project string, repository string, ar harbor.ArtifactsResult) Artifact {
+	tags := make([]string, len(ar.Tags))
+	for i, t := range ar.Tags {
+		tags[i] = t.Name
+	}
+	name := ar.Digest
+	if len(tags) > 0 {
+		name = tags[0]
 	}
 
-	if result {
-		fmt.Println("Artifact is in USE")
-		canDelete = true
-	} else {
-		fmt.Println("Artifact is NOT in USE")
+	return Artifact{
+		Selected:    false,
+		Name:        name,
+		Tags:        tags,
+		Labels:      ar.Labels,
+		Project:     project,
+		Repository:  repository,
+		Hash:        ar.Digest,
+		MediaType:   ar.ManifestMediaType,
+		Attributes:  ar.ExtraAttrs,
+		Size:        float64(ar.Size),
+		PullTime:    ar.PullTime,
+		PushTime:    ar.PushTime,
+		Scan:        ar.Overview(),
+		Additions:   ar.AdditionLinks,
+		Signed:      ar.IsSigned(),
+		Sbom:        ar.HasSbom(),
+		Accessories: ar.Accessories,
 	}
+}
 
+func fetchArtifacts(ctx context.Context, project string, repository string, opts harbor.QueryOptions) tea.Cmd {
 	return func() tea.Msg {
-		return processDeleteArtifactMsg{
-			Artifact:  artifact,
-			canDelete: canDelete,
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			slog.Error("Error creating harbor client", "err", err)
+			return artifactsLoadedMsg{project: project, repository: repository, err: err}
+		}
+
+		a, err := harborClient.FetchArtifacts(ctx, project, repository, opts)
+		if err != nil {
+			slog.Error("Error fetching artifacts", "err", err)
+			return artifactsLoadedMsg{project: project, repository: repository, err: fmt.Errorf("failed to fetch artifacts: %w", err)}
+		}
+
+		// Children of every index are fetched at once, the shared limiter
+		// bounds the requests in flight
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		children := map[string][]harbor.ArtifactsResult{}
+		for _, ar := range *a {
+			if !ar.IsIndex() {
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c, err := harborClient.FetchChildArtifacts(ctx, project, repository, ar)
+				if err != nil {
+					// The index is still listed, only without its platforms
+					slog.Error("Error fetching index children", "index", ar.Digest, "err", err)
+					return
+				}
+
+				mu.Lock()
+				children[ar.Digest] = *c
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		if ctx.Err() != nil {
+			return artifactsLoadedMsg{project: project, repository: repository, err: ctx.Err()}
+		}
+
+		return artifactsLoadedMsg{project: project, repository: repository, artifacts: expandIndexes(project, repository, *a, children)}
+	}
+}
+
+// expandIndexes lists the artifacts with the platforms of each index, found
+// in children by index digest, right below it. An index missing from
+// children is listed alone.
+func expandIndexes(project string, repository string, results []harbor.ArtifactsResult, children map[string][]harbor.ArtifactsResult) []Artifact {
+	artifacts := make([]Artifact, 0, len(results))
+	for _, ar := range results {
+		artifact := newArtifact(project, repository, ar)
+		c, ok := children[ar.Digest]
+		if !ar.IsIndex() || !ok {
+			artifacts = append(artifacts, artifact)
+			continue
 		}
+
+		platforms := make([]Artifact, len(c))
+		for i, child := range c {
+			platforms[i] = newArtifact(project, repository, child)
+			platforms[i].Parent = ar.Digest
+			for _, r := range ar.References {
+				if r.ChildDigest == child.Digest && r.Platform != nil {
+					platforms[i].Platform = r.Platform.String()
+				}
+			}
+			// The index itself only weighs its manifest
+			artifact.Size += platforms[i].Size
+		}
+
+		artifacts = append(artifacts, artifact)
+		artifacts = append(artifacts, platforms...)
+	}
+
+	return artifacts
+}
+
+// getTargetArtifacts returns the selected artifacts or, when none is
+// selected, the artifact under the cursor.
+func getTargetArtifacts(artifacsState ArtifactsState) []Artifact {
+	selected := getSelectedArtifacts(artifacsState)
+	if len(selected) > 0 || len(artifacsState.data) == 0 {
+		return selected
+	}
+
+	return []Artifact{artifacsState.data[artifacsState.table.Cursor()]}
+}
+
+// scanTargets lists each top-level artifact once, Harbor scans the platforms
+// of an index along with it.
+func scanTargets(artifacts []Artifact) []Artifact {
+	targets := []Artifact{}
+	seen := map[string]bool{}
+	for _, a := range artifacts {
+		if a.Parent != "" || seen[a.Hash] {
+			continue
+		}
+		seen[a.Hash] = true
+		targets = append(targets, a)
+	}
+	return targets
+}
+
+// repositoryTags returns every tag of the loaded artifacts.
+func repositoryTags(artifacts []Artifact) []string {
+	tags := []string{}
+	for _, a := range artifacts {
+		tags = append(tags, a.Tags...)
+	}
+	return tags
+}
+
+func artifactRows(artifacts []Artifact) []table.Row {
+	rows := make([]table.Row, len(artifacts))
+	for i, a := range artifacts {
+		rows[i] = a.ToRow()
 	}
+	return rows
 }
 
 func getSelectedArtifacts(artifacsState ArtifactsState) []Artifact {
@@ -163,25 +362,122 @@ func getSelectedArtifacts(artifacsState ArtifactsState) []Artifact {
 }
 
 func (m model) artifactsView() string {
-	return m.state.artifacts.table.View()
+	state := m.state.artifacts
+	content := colorLabelsColumn(state.table.View(), state.data)
+
+	if len(state.data) > 0 {
+		// Labels that do not fit in their column are listed below the table
+		active := state.data[state.table.Cursor()]
+		labels := renderLabels(active.Labels)
+		if ansi.StringWidth(labels) > ARTIFACTS_COLUMNS[artifactsLabelsColumn].Width {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, "Labels: "+labels)
+		}
+	}
+
+	return withPrompt(content, state.prompt)
+}
+
+// backToArtifacts returns from an artifact detail page to the artifacts
+// table, resuming the scan polling that stopped while away.
+func (m model) backToArtifacts() (model, tea.Cmd) {
+	var cmd tea.Cmd
+	m = m.SwitchPage(artifactsPage)
+	m.state.artifacts, cmd = m.state.artifacts.pollScans()
+	return m, cmd
 }
 
 func (m model) artifactsUpdate(msg tea.Msg) (model, tea.Cmd) {
 	var cmd tea.Cmd
+	if m.state.artifacts.prompt.handles(msg) {
+		action := m.state.artifacts.prompt.action
+		prompt, value, submitted, cmd := m.state.artifacts.prompt.update(msg)
+		m.state.artifacts.prompt = prompt
+		if !submitted {
+			return m, cmd
+		}
+
+		switch action {
+		case filterPromptAction:
+			if _, err := parseArtifactFilter(value, m.state.artifacts.labels, time.Now()); err != nil {
+				m = m.setError(err)
+				return m, nil
+			}
+			m.state.artifacts.filter = value
+			m.state.artifacts = newEmptyArtifactsState(m.state.artifacts, "Loading...")
+			return m, m.state.artifacts.fetch()
+		case addTagPromptAction, deleteTagPromptAction, moveTagPromptAction:
+			if value == "" || len(m.state.artifacts.data) == 0 {
+				return m, nil
+			}
+			active := m.state.artifacts.data[m.state.artifacts.table.Cursor()]
+			return m, updateTag(m.state.artifacts.ctx, action, active, value)
+		case packageSearchPromptAction:
+			if value == "" {
+				return m, nil
+			}
+			state := m.state.artifacts
+			m.state.packageSearch, cmd = m.NewPackageSearchState(state.project, []string{state.repository}, value, artifactsPage)
+			m = m.SwitchPage(packageSearchPage)
+			return m, cmd
+		case deleteArtifactsPromptAction:
+			if value != "yes" {
+				return m, nil
+			}
+			return m, deleteArtifacts(m.state.artifacts.ctx, getSelectedArtifacts(m.state.artifacts))
+		case copyArtifactsPromptAction:
+			if value == "" {
+				return m, nil
+			}
+			return m.copyArtifacts(getTargetArtifacts(m.state.artifacts), value)
+		case addLabelPromptAction, removeLabelPromptAction:
+			label, ok := findLabel(m.state.artifacts.labels, value)
+			if !ok {
+				m = m.setError(fmt.Errorf("unknown label %s", value))
+				return m, nil
+			}
+			targets := getTargetArtifacts(m.state.artifacts)
+			return m, updateArtifactsLabel(m.state.artifacts.ctx, action, targets, label)
+		}
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
-	case ArtifactDeleteMsg:
+	case artifactsLoadedMsg:
+		if msg.project != m.state.artifacts.project || msg.repository != m.state.artifacts.repository {
+			// Late result of a repository the user already left
+			return m, nil
+		}
 		if msg.err != nil {
-			fmt.Printf("error: %s\n", msg.err.Error())
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.artifacts = newEmptyArtifactsState(m.state.artifacts, "No data available")
+			m = m.setError(msg.err)
 			return m, nil
 		}
 
-		fmt.Printf("Deleted artifact with hash: %s\n", msg.artifact.Hash)
-	case processDeleteArtifactMsg:
-		if msg.canDelete {
-			fmt.Println("processDoneMsg!!!")
-		} else {
-			fmt.Println("processErrorMsg!!!")
+		m.state.artifacts = newArtifactsState(m.state.artifacts, msg.artifacts)
+		m.state.artifacts, cmd = m.state.artifacts.pollScans()
+		return m, cmd
+	case artifactScanMsg, scanPollMsg, scanStatusMsg:
+		return m.scanUpdate(msg)
+	case tagMsg:
+		return m.tagUpdate(msg)
+	case artifactCopiedMsg:
+		return m.artifactCopiedUpdate(msg)
+	case labelsLoadedMsg, artifactsLabelMsg:
+		return m.labelUpdate(msg)
+	case ArtifactDeleteMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			slog.Error("Error deleting artifact", "err", msg.err)
+			m = m.setError(fmt.Errorf("failed to delete %s: %w", msg.artifact.Name, msg.err))
+			return m, nil
 		}
+
+		m = m.setInfo(fmt.Sprintf("Deleted artifact %s (%s)", msg.artifact.Name, msg.artifact.Hash))
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "c":
@@ -198,21 +494,180 @@ func (m model) artifactsUpdate(msg tea.Msg) (model, tea.Cmd) {
 		case "d":
 			// Delete selected artifacts
 			selected := getSelectedArtifacts(m.state.artifacts)
-			cmds := []tea.Cmd{}
 
+			// Accessories go away with their subject, make sure that is intended
+			accessories := 0
 			for _, s := range selected {
-				// cmds = append(cmds, processDeleteArtifact(s))
-				cmds = append(cmds, deleteArtifact(s))
+				accessories += len(s.Accessories)
+			}
+			if accessories > 0 {
+				label := fmt.Sprintf("Delete %d artifacts along with their %d accessories? Type yes to confirm", len(selected), accessories)
+				m.state.artifacts.prompt, cmd = newPrompt(label, "", deleteArtifactsPromptAction)
+				return m, cmd
+			}
+
+			return m, deleteArtifacts(m.state.artifacts.ctx, selected)
+		case "/":
+			// Filter artifacts by tag, label, push or pull time
+			m.state.artifacts.prompt, cmd = newPrompt("Filter (tag tagged= label= pushed= pulled=)", m.state.artifacts.filter, filterPromptAction)
+			m.state.artifacts.prompt = m.state.artifacts.prompt.withSuggestions(artifactFilterSuggestions)
+			return m, cmd
+		case "s", "x":
+			// Scan, or stop scanning, the selected artifacts
+			cmds := []tea.Cmd{}
+			for _, a := range getTargetArtifacts(m.state.artifacts) {
+				cmds = append(cmds, scanArtifact(m.state.artifacts.ctx, a, msg.String() == "x"))
+			}
+
+			return m, tea.Batch(cmds...)
+		case "S":
+			// Scan every artifact of the repository
+			cmds := []tea.Cmd{}
+			for _, a := range scanTargets(m.state.artifacts.data) {
+				cmds = append(cmds, scanArtifact(m.state.artifacts.ctx, a, false))
 			}
 
 			return m, tea.Batch(cmds...)
+		case "t", "r", "m":
+			// Add, remove or move a tag onto the artifact under the cursor
+			if len(m.state.artifacts.data) == 0 {
+				return m, nil
+			}
+			active := m.state.artifacts.data[m.state.artifacts.table.Cursor()]
+			switch msg.String() {
+			case "t":
+				m.state.artifacts.prompt, cmd = newPrompt("New tag", "", addTagPromptAction)
+			case "r":
+				value := ""
+				if len(active.Tags) == 1 {
+					value = active.Tags[0]
+				}
+				m.state.artifacts.prompt, cmd = newPrompt("Remove tag", value, deleteTagPromptAction)
+				m.state.artifacts.prompt = m.state.artifacts.prompt.withSuggestions(active.Tags)
+			case "m":
+				m.state.artifacts.prompt, cmd = newPrompt("Move tag here", "", moveTagPromptAction)
+				m.state.artifacts.prompt = m.state.artifacts.prompt.withSuggestions(repositoryTags(m.state.artifacts.data))
+			}
+			return m, cmd
+		case "l":
+			// Attach a label to the selected artifacts
+			m.state.artifacts.prompt, cmd = newPrompt("Attach label", "", addLabelPromptAction)
+			m.state.artifacts.prompt = m.state.artifacts.prompt.withSuggestions(labelNames(m.state.artifacts.labels))
+			return m, cmd
+		case "L":
+			// Detach a label from the selected artifacts
+			attached := []harbor.Label{}
+			for _, a := range getTargetArtifacts(m.state.artifacts) {
+				attached = append(attached, a.Labels...)
+			}
+			m.state.artifacts.prompt, cmd = newPrompt("Detach label", "", removeLabelPromptAction)
+			m.state.artifacts.prompt = m.state.artifacts.prompt.withSuggestions(labelNames(attached))
+			return m, cmd
+		case "i":
+			// Inspect the artifact under the cursor
+			if len(m.state.artifacts.data) == 0 {
+				return m, nil
+			}
+			active := m.state.artifacts.data[m.state.artifacts.table.Cursor()]
+			platforms := []Artifact{}
+			for _, a := range m.state.artifacts.data {
+				if a.Parent == active.Hash {
+					platforms = append(platforms, a)
+				}
+			}
+			m.state.inspect, cmd = m.NewInspectState(active, platforms)
+			m = m.SwitchPage(inspectPage)
+			return m, cmd
+		case "b":
+			// Show the SBOM of the artifact under the cursor
+			if len(m.state.artifacts.data) == 0 {
+				return m, nil
+			}
+			active := m.state.artifacts.data[m.state.artifacts.table.Cursor()]
+			m.state.sbom, cmd = m.NewSbomState(active)
+			m = m.SwitchPage(sbomPage)
+			return m, cmd
+		case "A":
+			// Show the history of the repository
+			state := m.state.artifacts
+			scope := fmt.Sprintf("%s/%s", state.project, displayName(state.repository))
+			m.state.audit, cmd = m.NewAuditState(state.project, scope, artifactsPage)
+			m = m.SwitchPage(auditPage)
+			return m, cmd
+		case "p":
+			// Copy, or promote, the selected artifacts to another repository
+			targets := getTargetArtifacts(m.state.artifacts)
+			if len(targets) == 0 {
+				return m, nil
+			}
+			state := m.state.artifacts
+			label := fmt.Sprintf("Copy %d artifacts to (project/repository[:tag])", len(targets))
+			value := fmt.Sprintf("%s/%s", state.project, displayName(state.repository))
+			m.state.artifacts.prompt, cmd = newPrompt(label, value, copyArtifactsPromptAction)
+			m.state.artifacts.prompt = m.state.artifacts.prompt.withSuggestions(m.copyTargetSuggestions())
+			return m, cmd
+		case "P":
+			// Search a package in the SBOMs of the repository
+			m.state.artifacts.prompt, cmd = newPrompt("Search package (name@version)", "", packageSearchPromptAction)
+			return m, cmd
+		case "a":
+			// Show signatures, SBOMs and attestations of the artifact under the cursor
+			if len(m.state.artifacts.data) == 0 {
+				return m, nil
+			}
+			active := m.state.artifacts.data[m.state.artifacts.table.Cursor()]
+			m.state.accessories, cmd = m.NewAccessoriesState(active)
+			m = m.SwitchPage(accessoriesPage)
+			return m, cmd
+		case "h":
+			// Show how the artifact under the cursor was built
+			if len(m.state.artifacts.data) == 0 {
+				return m, nil
+			}
+			active := m.state.artifacts.data[m.state.artifacts.table.Cursor()]
+			m.state.buildHistory, cmd = m.NewBuildHistoryState(active)
+			m = m.SwitchPage(buildHistoryPage)
+			return m, cmd
+		case "v":
+			// Show vulnerabilities of the artifact under the cursor
+			if len(m.state.artifacts.data) == 0 {
+				return m, nil
+			}
+			active := m.state.artifacts.data[m.state.artifacts.table.Cursor()]
+			m.state.vulnerabilities, cmd = m.NewVulnerabilitiesState(active)
+			m = m.SwitchPage(vulnerabilitiesPage)
+			return m, cmd
+		case "esc":
+			// Abort pending fetches and deletions
+			m.state.artifacts.cancel()
+			m.state.artifacts.ctx, m.state.artifacts.cancel = newPageContext()
+			if len(m.state.artifacts.data) == 0 {
+				m.state.artifacts = newEmptyArtifactsState(m.state.artifacts, "Cancelled, press u to reload")
+			}
+			m = m.setInfo("Cancelled pending requests")
+			return m, nil
+		case "u":
+			// Refresh
+			m.state.artifacts = newEmptyArtifactsState(m.state.artifacts, "Loading...")
+			return m, m.state.artifacts.fetch()
 		case "-":
 			// Go back
+			m.state.artifacts.cancel()
 			m = m.SwitchPage(repositoriesPage)
-			return m, nil
+			if m.state.repositories.project != m.state.artifacts.project {
+				// Artifacts were opened from the search page
+				m.state.repositories, cmd = m.NewRepositoriesState(m.state.artifacts.project)
+			}
+			return m, cmd
 		case " ":
 			// Check artifact for deletion
+			if len(m.state.artifacts.data) == 0 {
+				return m, nil
+			}
 			rowIndex := m.state.artifacts.table.Cursor()
+			if m.state.artifacts.data[rowIndex].Parent != "" {
+				return m, nil
+			}
 			m.state.artifacts.data[rowIndex].Selected = !m.state.artifacts.data[rowIndex].Selected
 
 			rows := make([]table.Row, len(m.state.artifacts.data))
@@ -229,65 +684,44 @@ func (m model) artifactsUpdate(msg tea.Msg) (model, tea.Cmd) {
 	return m, cmd
 }
 
+// Columns of the artifacts table that are drawn again once rendered.
+const (
+	artifactsHashColumn   = 2
+	artifactsLabelsColumn = 3
+)
+
 var ARTIFACTS_COLUMNS = []table.Column{
 	{Title: "Select", Width: 6},
-	{Title: "Tag", Width: 25},
+	{Title: "Tags", Width: 25},
 	{Title: "sha256", Width: 15},
 	{Title: "Labels", Width: 20},
+	{Title: "Sig/SBOM", Width: 8},
+	{Title: "Vulns C/H/M/L", Width: 15},
 	{Title: "Size (MiB)", Width: 10},
 	{Title: "Pull time", Width: 25},
 	{Title: "Push time", Width: 25},
 }
 
-func newEmptyArtifactsState() ArtifactsState {
+// newEmptyArtifactsState keeps the request context of state and shows
+// message instead of data.
+func newEmptyArtifactsState(state ArtifactsState, message string) ArtifactsState {
 	t := table.New(
 		table.WithColumns(ARTIFACTS_COLUMNS),
-		table.WithRows([]table.Row{{"", "No data available", "", "", "", "", ""}}),
+		table.WithRows([]table.Row{{"", message, "", "", "", "", "", "", ""}}),
 		table.WithFocused(true),
 		table.WithHeight(2),
 	)
 
 	t.SetStyles(GetTableDefaultStyles())
 
-	state := ArtifactsState{
-		table: t,
-		data:  []Artifact{},
-	}
+	state.table = t
+	state.data = []Artifact{}
 
 	return state
 }
 
-func (m model) NewArtifactsState(project string, repository string) ArtifactsState {
-	harborClient, err := harbor.NewHarborApiClient(http.DefaultClient)
-	if err != nil {
-		slog.Error("Error creating harbor client", "err", err)
-		return newEmptyArtifactsState()
-	}
-
-	a, err := harborClient.FetchArtifacts(project, repository)
-	if err != nil {
-		slog.Error("Error fetching artifacts", "err", err)
-		return newEmptyArtifactsState()
-	}
-
-	artifacts := make([]Artifact, len(*a))
-	for i, ar := range *a {
-		tag := ar.Tags[0]
-		artifact := Artifact{
-			Selected:   false,
-			Name:       tag.Name,
-			Project:    project,
-			Repository: repository,
-			Hash:       ar.Digest,
-			Size:       float64(ar.Size),
-			PullTime:   ar.PullTime,
-			PushTime:   ar.PushTime,
-		}
-
-		artifacts[i] = artifact
-	}
-
-	rows := make([]table.Row, len(*a))
+func newArtifactsState(state ArtifactsState, artifacts []Artifact) ArtifactsState {
+	rows := make([]table.Row, len(artifacts))
 	for i, a := range artifacts {
 		rows[i] = a.ToRow()
 	}
@@ -301,12 +735,113 @@ func (m model) NewArtifactsState(project string, repository string) ArtifactsSta
 
 	t.SetStyles(GetTableDefaultStyles())
 
-	state := ArtifactsState{
-		table: t,
-		data:  artifacts,
-	}
+	state.table = t
+	state.data = artifacts
 
 	slog.Debug("New Artifact state created.")
 
 	return state
 }
+
+// NewArtifactsState returns a loading artifacts state for the repository,
+// showing only tags matching filter, along with the command that fetches
+// its data.
+func (m model) NewArtifactsState(project string, repository string, filter string) (ArtifactsState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := ArtifactsState{
+		project:    project,
+		repository: repository,
+		filter:     filter,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	return newEmptyArtifactsState(state, "Loading..."), tea.Batch(state.fetch(), fetchLabels(ctx, project))
+}
+
+var artifactFilterSuggestions = []string{
+	"tagged=yes",
+	"tagged=no",
+	"label=",
+	"pushed=7d",
+	"pulled=30d",
+}
+
+// parseArtifactFilter reads the artifact filter. A plain word is matched
+// against the tags, key=value words filter on tagged (yes or no), label (the
+// artifacts must have every given label), pushed and pulled (within an age
+// such as 24h or 7d).
+func parseArtifactFilter(filter string, labels []harbor.Label, now time.Time) (harbor.QueryOptions, error) {
+	opts := harbor.QueryOptions{}
+	for _, field := range strings.Fields(filter) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			if opts.Tag != "" {
+				return opts, fmt.Errorf("invalid filter %q, only one tag can be matched", field)
+			}
+			opts.Tag = field
+			continue
+		}
+		if value == "" {
+			return opts, fmt.Errorf("invalid filter %q, expected key=value", field)
+		}
+
+		switch key {
+		case "tagged":
+			if value != "yes" && value != "no" {
+				return opts, fmt.Errorf("invalid filter %q, use tagged=yes or tagged=no", field)
+			}
+			tagged := value == "yes"
+			opts.Tagged = &tagged
+		case "label":
+			label, ok := findLabel(labels, value)
+			if !ok {
+				return opts, fmt.Errorf("unknown label %s", value)
+			}
+			opts.LabelIds = append(opts.LabelIds, label.Id)
+		case "pushed", "pulled":
+			d, err := parseAge(value)
+			if err != nil {
+				return opts, err
+			}
+			if key == "pushed" {
+				opts.PushTime.From = now.Add(-d)
+			} else {
+				opts.PullTime.From = now.Add(-d)
+			}
+		default:
+			return opts, fmt.Errorf("unknown filter %q, use a tag, tagged, label, pushed or pulled", key)
+		}
+	}
+	_, err := opts.Values()
+	return opts, err
+}
+
+// fetch loads the artifacts matching the current filter, newest first.
+func (s ArtifactsState) fetch() tea.Cmd {
+	opts, err := parseArtifactFilter(s.filter, s.labels, time.Now())
+	if err != nil {
+		return func() tea.Msg {
+			return artifactsLoadedMsg{project: s.project, repository: s.repository, err: err}
+		}
+	}
+	opts.Sort = []string{"-push_time"}
+	return fetchArtifacts(s.ctx, s.project, s.repository, opts)
+}
+
+// parseAge reads durations such as 90m, 24h or 7d.
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q: %w", value, err)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q: %w", value, err)
+	}
+	return d, nil
+}
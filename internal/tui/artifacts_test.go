@@ -0,0 +1,229 @@
+package tui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestExpandIndexes(t *testing.T) {
+	amd64 := harbor.ArtifactsResult{Digest: "sha256:amd64", Size: 100}
+	arm64 := harbor.ArtifactsResult{Digest: "sha256:arm64", Size: 200}
+	index := harbor.ArtifactsResult{
+		Digest: "sha256:index",
+		Size:   10,
+		Tags:   []harbor.Tag{{Name: "v1"}},
+		References: []harbor.Reference{
+			{ChildDigest: "sha256:amd64", Platform: &harbor.Platform{Os: "linux", Architecture: "amd64"}},
+			{ChildDigest: "sha256:arm64", Platform: &harbor.Platform{Os: "linux", Architecture: "arm64", Variant: "v8"}},
+		},
+	}
+	image := harbor.ArtifactsResult{Digest: "sha256:image", Size: 50, Tags: []harbor.Tag{{Name: "v0"}}}
+
+	type row struct {
+		hash     string
+		parent   string
+		platform string
+		size     float64
+	}
+
+	tests := []struct {
+		name     string
+		results  []harbor.ArtifactsResult
+		children map[string][]harbor.ArtifactsResult
+		want     []row
+	}{
+		{
+			name:    "plain images",
+			results: []harbor.ArtifactsResult{image},
+			want:    []row{{hash: "sha256:image", size: 50}},
+		},
+		{
+			name:     "index followed by its platforms",
+			results:  []harbor.ArtifactsResult{index, image},
+			children: map[string][]harbor.ArtifactsResult{"sha256:index": {amd64, arm64}},
+			want: []row{
+				{hash: "sha256:index", size: 310},
+				{hash: "sha256:amd64", parent: "sha256:index", platform: "linux/amd64", size: 100},
+				{hash: "sha256:arm64", parent: "sha256:index", platform: "linux/arm64/v8", size: 200},
+				{hash: "sha256:image", size: 50},
+			},
+		},
+		{
+			name:     "children matched by digest",
+			results:  []harbor.ArtifactsResult{index},
+			children: map[string][]harbor.ArtifactsResult{"sha256:index": {arm64, amd64}},
+			want: []row{
+				{hash: "sha256:index", size: 310},
+				{hash: "sha256:arm64", parent: "sha256:index", platform: "linux/arm64/v8", size: 200},
+				{hash: "sha256:amd64", parent: "sha256:index", platform: "linux/amd64", size: 100},
+			},
+		},
+		{
+			name:     "index whose children could not be fetched",
+			results:  []harbor.ArtifactsResult{index, image},
+			children: map[string][]harbor.ArtifactsResult{},
+			want: []row{
+				{hash: "sha256:index", size: 10},
+				{hash: "sha256:image", size: 50},
+			},
+		},
+		{
+			name:     "child also listed on its own",
+			results:  []harbor.ArtifactsResult{index, amd64},
+			children: map[string][]harbor.ArtifactsResult{"sha256:index": {amd64, arm64}},
+			want: []row{
+				{hash: "sha256:index", size: 310},
+				{hash: "sha256:amd64", parent: "sha256:index", platform: "linux/amd64", size: 100},
+				{hash: "sha256:arm64", parent: "sha256:index", platform: "linux/arm64/v8", size: 200},
+				{hash: "sha256:amd64", size: 100},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandIndexes("library", "app", tt.results, tt.children)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expandIndexes() returned %d artifacts, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				a := got[i]
+				if a.Hash != w.hash || a.Parent != w.parent || a.Platform != w.platform || a.Size != w.size {
+					t.Errorf("artifact %d = %s parent %q platform %q size %.0f, want %s parent %q platform %q size %.0f",
+						i, a.Hash, a.Parent, a.Platform, a.Size, w.hash, w.parent, w.platform, w.size)
+				}
+				if a.Project != "library" || a.Repository != "app" {
+					t.Errorf("artifact %d belongs to %s/%s", i, a.Project, a.Repository)
+				}
+			}
+		})
+	}
+}
+
+func TestScanTargets(t *testing.T) {
+	artifacts := []Artifact{
+		{Hash: "sha256:index"},
+		{Hash: "sha256:amd64", Parent: "sha256:index"},
+		{Hash: "sha256:arm64", Parent: "sha256:index"},
+		{Hash: "sha256:amd64"},
+		{Hash: "sha256:other"},
+		{Hash: "sha256:other"},
+	}
+
+	got := []string{}
+	for _, a := range scanTargets(artifacts) {
+		got = append(got, a.Hash)
+	}
+	want := []string{"sha256:index", "sha256:amd64", "sha256:other"}
+	if !slices.Equal(got, want) {
+		t.Errorf("scanTargets() = %v, want %v", got, want)
+	}
+}
+
+func TestArtifactsLoadedDropsLateResults(t *testing.T) {
+	m := model{}
+	m.state.artifacts = newEmptyArtifactsState(ArtifactsState{project: "library", repository: "app"}, "Loading...")
+
+	m, _ = m.artifactsUpdate(artifactsLoadedMsg{project: "library", repository: "old", artifacts: []Artifact{{Hash: "sha256:old"}}})
+	if len(m.state.artifacts.data) != 0 {
+		t.Errorf("artifacts of a repository left were shown")
+	}
+
+	m, _ = m.artifactsUpdate(artifactsLoadedMsg{project: "library", repository: "app", err: context.Canceled})
+	if m.state.footer.message != "" {
+		t.Errorf("cancelled load reported %q", m.state.footer.message)
+	}
+}
+
+func TestParseArtifactFilter(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	yes, no := true, false
+	labels := []harbor.Label{{Id: 1, Name: "prod"}, {Id: 2, Name: "stable"}}
+
+	tests := []struct {
+		filter  string
+		want    harbor.QueryOptions
+		wantErr bool
+	}{
+		{filter: "", want: harbor.QueryOptions{}},
+		{filter: "v1", want: harbor.QueryOptions{Tag: "v1"}},
+		{filter: "tagged=yes", want: harbor.QueryOptions{Tagged: &yes}},
+		{filter: "tagged=no", want: harbor.QueryOptions{Tagged: &no}},
+		{
+			filter: "v1 pushed=7d pulled=24h",
+			want: harbor.QueryOptions{
+				Tag:      "v1",
+				PushTime: harbor.TimeRange{From: now.Add(-7 * 24 * time.Hour)},
+				PullTime: harbor.TimeRange{From: now.Add(-24 * time.Hour)},
+			},
+		},
+		{filter: "label=prod label=stable", want: harbor.QueryOptions{LabelIds: []int{1, 2}}},
+		{filter: "label=beta", wantErr: true},
+		{filter: "v1 v2", wantErr: true},
+		{filter: "tagged=maybe", wantErr: true},
+		{filter: "pushed=soon", wantErr: true},
+		{filter: "pushed=", wantErr: true},
+		{filter: "size=10", wantErr: true},
+		{filter: "v1,tags=*", wantErr: true},
+		{filter: "v~1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got, err := parseArtifactFilter(tt.filter, labels, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseArtifactFilter(%q) error = %v, wantErr %t", tt.filter, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseArtifactFilter(%q) = %+v, want %+v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindContainersOfImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			w.Write([]byte(`{"jwt": "token"}`))
+		case "/api/endpoints":
+			w.Write([]byte(`[{"Id": 1, "Name": "down"}, {"Id": 2, "Name": "up"}]`))
+		case "/api/endpoints/1/docker/containers/json":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/api/endpoints/2/docker/containers/json":
+			w.Write([]byte(`[
+				{"Id": "a", "Image": "harbor.example.com/library/app:v1@sha256:wanted"},
+				{"Id": "b", "Image": "harbor.example.com/library/app:v2@sha256:other"},
+				{"Id": "c", "Image": "nginx"}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("PORTAINER_BASEURL", server.URL)
+	t.Setenv("LDAP_USERNAME", "user")
+	t.Setenv("LDAP_PASSWORD", "password")
+
+	found, err := FindContainersOfImages(context.Background(), []string{"sha256:wanted", "sha256:absent"})
+	if err != nil {
+		t.Fatalf("FindContainersOfImages() error = %v, an unreachable endpoint must be skipped", err)
+	}
+	if len(found["sha256:wanted"]) != 1 || found["sha256:wanted"][0].Id != "a" {
+		t.Errorf("containers of sha256:wanted = %+v, want container a", found["sha256:wanted"])
+	}
+	if _, ok := found["sha256:other"]; ok {
+		t.Error("containers of an image not asked for were returned")
+	}
+	if len(found["sha256:absent"]) != 0 {
+		t.Errorf("containers of sha256:absent = %+v, want none", found["sha256:absent"])
+	}
+}
@@ -0,0 +1,369 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+// auditPageSize is how many logs are loaded at once.
+const auditPageSize = 100
+
+var auditFilterSuggestions = []string{
+	"operation=create",
+	"operation=delete",
+	"operation=pull",
+	"user=",
+	"resource=",
+	"since=24h",
+	"since=7d",
+	"from=",
+	"to=",
+}
+
+func auditLogRow(l harbor.AuditLogResult) []string {
+	return []string{
+		l.OpTime,
+		l.Username,
+		l.Operation,
+		l.ResourceType,
+		l.Resource,
+	}
+}
+
+type AuditState struct {
+	table table.Model
+	data  []harbor.AuditLogResult
+	// project is empty when browsing the system audit logs
+	project string
+	// home is the project the page was opened for
+	home string
+	// scope is the resource every log must match, set when browsing the
+	// history of a repository
+	scope  string
+	filter string
+	opts   harbor.QueryOptions
+	page   int
+	// query identifies the current filters, results of previous filters are
+	// dropped
+	query int
+	// more is true while older logs may be loaded
+	more   bool
+	prompt PromptState
+	from   page
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type auditLoadedMsg struct {
+	logs  []harbor.AuditLogResult
+	query int
+	page  int
+	err   error
+}
+
+// fetchAuditLogs loads a page of the logs of the state's current query.
+func (s AuditState) fetchAuditLogs(page int) tea.Cmd {
+	ctx, project, opts, query := s.ctx, s.project, s.options(), s.query
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return auditLoadedMsg{query: query, page: page, err: err}
+		}
+
+		l, err := harborClient.FetchAuditLogs(ctx, project, opts, page, auditPageSize)
+		if err != nil {
+			slog.Error("Error fetching audit logs", "err", err)
+			return auditLoadedMsg{query: query, page: page, err: fmt.Errorf("failed to fetch audit logs: %w", err)}
+		}
+
+		return auditLoadedMsg{logs: *l, query: query, page: page}
+	}
+}
+
+// parseAuditFilter reads space separated key=value filters. Keys are
+// operation, user, resource, since (e.g. 24h or 7d), from and to (dates
+// such as 2006-01-02 or 2006-01-02T15:04).
+func parseAuditFilter(filter string, now time.Time) (harbor.QueryOptions, error) {
+	opts := harbor.QueryOptions{}
+	for _, field := range strings.Fields(filter) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || value == "" {
+			return opts, fmt.Errorf("invalid filter %q, expected key=value", field)
+		}
+
+		switch key {
+		case "operation", "op":
+			opts.Operation = value
+		case "user":
+			opts.Username = value
+		case "resource":
+			opts.Resource = value
+		case "since":
+			d, err := parseAge(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.OpTime.From = now.Add(-d)
+		case "from", "to":
+			t, err := parseDate(value)
+			if err != nil {
+				return opts, err
+			}
+			if key == "from" {
+				opts.OpTime.From = t
+			} else {
+				opts.OpTime.To = t
+			}
+		default:
+			return opts, fmt.Errorf("unknown filter %q, use operation, user, resource, since, from or to", key)
+		}
+	}
+	_, err := opts.Values()
+	return opts, err
+}
+
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, "2006-01-02T15:04", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected 2006-01-02 or 2006-01-02T15:04", value)
+}
+
+// inScope reports whether resource is the repository scope itself or one of
+// its tags or digests. Harbor matches resources fuzzily, the history of
+// library/nginx would otherwise show library/nginx-proxy too.
+func inScope(resource string, scope string) bool {
+	if scope == "" {
+		return true
+	}
+	rest, ok := strings.CutPrefix(resource, scope)
+	return ok && (rest == "" || strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "@"))
+}
+
+// checkScope refuses filters on resources outside of the scope of the state,
+// a repository history only shows logs of that repository.
+func (s AuditState) checkScope(opts harbor.QueryOptions) error {
+	if opts.Resource != "" && !inScope(opts.Resource, s.scope) {
+		return fmt.Errorf("only resources of %s can be filtered here", s.scope)
+	}
+	return nil
+}
+
+// scoped keeps the logs of the scope of the state.
+func (s AuditState) scoped(logs []harbor.AuditLogResult) []harbor.AuditLogResult {
+	kept := []harbor.AuditLogResult{}
+	for _, l := range logs {
+		if inScope(l.Resource, s.scope) {
+			kept = append(kept, l)
+		}
+	}
+	return kept
+}
+
+// options returns the filters of the state restricted to its scope.
+func (s AuditState) options() harbor.QueryOptions {
+	opts := s.opts
+	if s.scope != "" && opts.Resource == "" {
+		opts.Resource = s.scope
+	}
+	return opts
+}
+
+func (s AuditState) title() string {
+	title := "System audit logs"
+	if s.project != "" {
+		title = fmt.Sprintf("Audit logs of project %s", s.project)
+	}
+	if s.scope != "" {
+		title = fmt.Sprintf("%s, %s only", title, s.scope)
+	}
+	if s.filter != "" {
+		title = fmt.Sprintf("%s (%s)", title, s.filter)
+	}
+	return title
+}
+
+func (m model) auditView() string {
+	state := m.state.audit
+	content := lipgloss.JoinVertical(lipgloss.Left, sectionTitleStyle.Render(state.title()), state.table.View())
+	return withPrompt(content, state.prompt)
+}
+
+// reloadAudit fetches the first page of logs again, e.g. after the filters
+// changed.
+func (m model) reloadAudit() (model, tea.Cmd) {
+	state := m.state.audit
+	state.page = 1
+	state.query++
+	m.state.audit = newEmptyAuditState(state, "Loading...")
+	return m, m.state.audit.fetchAuditLogs(1)
+}
+
+func (m model) auditUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.state.audit.prompt.handles(msg) {
+		action := m.state.audit.prompt.action
+		prompt, value, submitted, cmd := m.state.audit.prompt.update(msg)
+		m.state.audit.prompt = prompt
+		if submitted && action == filterPromptAction {
+			opts, err := parseAuditFilter(value, time.Now())
+			if err == nil {
+				err = m.state.audit.checkScope(opts)
+			}
+			if err != nil {
+				m = m.setError(err)
+				return m, nil
+			}
+			m.state.audit.filter = value
+			m.state.audit.opts = opts
+			return m.reloadAudit()
+		}
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case auditLoadedMsg:
+		if msg.query != m.state.audit.query || (msg.page > 1 && msg.page != m.state.audit.page+1) {
+			// Result of filters that changed since, or a page already loaded
+			return m, nil
+		}
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			if msg.page == 1 {
+				m.state.audit = newEmptyAuditState(m.state.audit, "No data available")
+			}
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		state := m.state.audit
+		logs := state.scoped(msg.logs)
+		if msg.page > 1 {
+			logs = append(state.data, logs...)
+		}
+		cursor := state.table.Cursor()
+		state.page = msg.page
+		state.more = len(msg.logs) == auditPageSize
+		state = newAuditState(state, logs)
+		state.table.SetCursor(cursor)
+		m.state.audit = state
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			m.state.audit.cancel()
+			if m.state.audit.from == artifactsPage {
+				return m.backToArtifacts()
+			}
+			m = m.SwitchPage(m.state.audit.from)
+			return m, nil
+		case "/":
+			m.state.audit.prompt, cmd = newPrompt("Filter (operation= user= resource= since= from= to=)", m.state.audit.filter, filterPromptAction)
+			m.state.audit.prompt = m.state.audit.prompt.withSuggestions(auditFilterSuggestions)
+			return m, cmd
+		case "g":
+			// Switch between the project and the system audit logs
+			if m.state.audit.home == "" {
+				return m, nil
+			}
+			if m.state.audit.project == "" {
+				m.state.audit.project = m.state.audit.home
+			} else {
+				m.state.audit.project = ""
+			}
+			return m.reloadAudit()
+		case "n":
+			// Load older logs
+			state := m.state.audit
+			if !state.more {
+				m = m.setInfo("No older audit logs")
+				return m, nil
+			}
+			return m, state.fetchAuditLogs(state.page + 1)
+		}
+	}
+
+	m.state.audit.table, cmd = m.state.audit.table.Update(msg)
+	return m, cmd
+}
+
+var AUDIT_COLUMNS = []table.Column{
+	{Title: "Time", Width: 26},
+	{Title: "User", Width: 20},
+	{Title: "Operation", Width: 10},
+	{Title: "Type", Width: 10},
+	{Title: "Resource", Width: 60},
+}
+
+// newEmptyAuditState keeps the request context of state and shows message
+// instead of data.
+func newEmptyAuditState(state AuditState, message string) AuditState {
+	t := table.New(
+		table.WithColumns(AUDIT_COLUMNS),
+		table.WithRows([]table.Row{{message, "", "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []harbor.AuditLogResult{}
+
+	return state
+}
+
+func newAuditState(state AuditState, logs []harbor.AuditLogResult) AuditState {
+	rows := make([]table.Row, len(logs))
+	for i, l := range logs {
+		rows[i] = auditLogRow(l)
+	}
+
+	t := table.New(
+		table.WithColumns(AUDIT_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = logs
+
+	slog.Debug("New Audit state created.")
+
+	return state
+}
+
+// NewAuditState returns a loading state with the audit logs of project,
+// restricted to the resources matching scope when not empty, along with the
+// command that fetches them. from is the page to return to.
+func (m model) NewAuditState(project string, scope string, from page) (AuditState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := AuditState{
+		project: project,
+		home:    project,
+		scope:   scope,
+		page:    1,
+		from:    from,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	return newEmptyAuditState(state, "Loading..."), state.fetchAuditLogs(1)
+}
@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestParseAuditFilter(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		filter  string
+		want    harbor.QueryOptions
+		wantErr bool
+	}{
+		{
+			name:   "empty",
+			filter: "",
+			want:   harbor.QueryOptions{},
+		},
+		{
+			name:   "operation user and resource",
+			filter: "operation=delete user=bob resource=library/nginx",
+			want:   harbor.QueryOptions{Operation: "delete", Username: "bob", Resource: "library/nginx"},
+		},
+		{
+			name:   "op shorthand and extra spaces",
+			filter: "  op=create   user=alice ",
+			want:   harbor.QueryOptions{Operation: "create", Username: "alice"},
+		},
+		{
+			name:   "since days",
+			filter: "since=7d",
+			want:   harbor.QueryOptions{OpTime: harbor.TimeRange{From: now.Add(-7 * 24 * time.Hour)}},
+		},
+		{
+			name:   "since hours",
+			filter: "since=90m",
+			want:   harbor.QueryOptions{OpTime: harbor.TimeRange{From: now.Add(-90 * time.Minute)}},
+		},
+		{
+			name:   "from and to",
+			filter: "from=2024-01-01 to=2024-01-31T18:30",
+			want: harbor.QueryOptions{OpTime: harbor.TimeRange{
+				From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+				To:   time.Date(2024, 1, 31, 18, 30, 0, 0, time.Local),
+			}},
+		},
+		{name: "missing value", filter: "user=", wantErr: true},
+		{name: "missing equal sign", filter: "bob", wantErr: true},
+		{name: "unknown key", filter: "project=library", wantErr: true},
+		{name: "invalid age", filter: "since=yesterday", wantErr: true},
+		{name: "invalid date", filter: "from=01/02/2024", wantErr: true},
+		{name: "comma in user", filter: "user=bob,operation=delete", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAuditFilter(tt.filter, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAuditFilter(%q) error = %v, wantErr %t", tt.filter, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAuditFilter(%q) = %+v, want %+v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "1d", want: 24 * time.Hour},
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: "24h", want: 24 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "1h30m", want: 90 * time.Minute},
+		{value: "d", wantErr: true},
+		{value: "1.5d", wantErr: true},
+		{value: "7", wantErr: true},
+		{value: "week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAge(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAge(%q) error = %v, wantErr %t", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAge(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAuditStateScope(t *testing.T) {
+	tests := []struct {
+		name         string
+		scope        string
+		resource     string
+		wantErr      bool
+		wantResource string
+	}{
+		{name: "no scope", scope: "", resource: "", wantResource: ""},
+		{name: "no scope with resource", scope: "", resource: "other/app", wantResource: "other/app"},
+		{name: "scope only", scope: "library/nginx", resource: "", wantResource: "library/nginx"},
+		{name: "resource inside scope", scope: "library/nginx", resource: "library/nginx:1.25", wantResource: "library/nginx:1.25"},
+		{name: "resource outside scope", scope: "library/nginx", resource: "other/app", wantErr: true},
+		{name: "digest inside scope", scope: "library/nginx", resource: "library/nginx@sha256:abc", wantResource: "library/nginx@sha256:abc"},
+		{name: "repository sharing the prefix", scope: "library/nginx", resource: "library/nginx-proxy", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := AuditState{scope: tt.scope, opts: harbor.QueryOptions{Resource: tt.resource}}
+			err := state.checkScope(state.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkScope() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := state.options().Resource; got != tt.wantResource {
+				t.Errorf("options().Resource = %q, want %q", got, tt.wantResource)
+			}
+		})
+	}
+}
+
+func TestAuditStateScoped(t *testing.T) {
+	logs := []harbor.AuditLogResult{
+		{Resource: "library/nginx"},
+		{Resource: "library/nginx:1.25"},
+		{Resource: "library/nginx@sha256:abc"},
+		{Resource: "library/nginx-proxy:latest"},
+		{Resource: "library/nginx2"},
+		{Resource: "other/library/nginx:1.25"},
+	}
+
+	got := []string{}
+	for _, l := range (AuditState{scope: "library/nginx"}).scoped(logs) {
+		got = append(got, l.Resource)
+	}
+	want := []string{"library/nginx", "library/nginx:1.25", "library/nginx@sha256:abc"}
+	if !slices.Equal(got, want) {
+		t.Errorf("scoped() = %v, want %v", got, want)
+	}
+
+	if got := (AuditState{}).scoped(logs); len(got) != len(logs) {
+		t.Errorf("scoped() without scope kept %d logs, want %d", len(got), len(logs))
+	}
+}
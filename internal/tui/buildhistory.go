@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+type Layer struct {
+	Created    string
+	CreatedBy  string
+	EmptyLayer bool
+}
+
+func (l Layer) ToRow() []string {
+	empty := ""
+	if l.EmptyLayer {
+		empty = "yes"
+	}
+
+	// Dockerfile instructions are usually recorded behind a shell prefix
+	command := strings.TrimPrefix(l.CreatedBy, "/bin/sh -c #(nop) ")
+
+	return []string{
+		l.Created,
+		empty,
+		command,
+	}
+}
+
+type BuildHistoryState struct {
+	table    table.Model
+	data     []Layer
+	artifact Artifact
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+type buildHistoryLoadedMsg struct {
+	layers []Layer
+	err    error
+}
+
+func fetchBuildHistory(ctx context.Context, artifact Artifact) tea.Cmd {
+	return func() tea.Msg {
+		if artifact.Additions.BuildHistory.Href == "" {
+			return buildHistoryLoadedMsg{err: fmt.Errorf("%s has no build history", artifact.Name)}
+		}
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			slog.Error("Error creating harbor client", "err", err)
+			return buildHistoryLoadedMsg{err: err}
+		}
+
+		history, err := harborClient.FetchBuildHistory(ctx, artifact.Additions.BuildHistory)
+		if err != nil {
+			slog.Error("Error fetching build history", "err", err)
+			return buildHistoryLoadedMsg{err: fmt.Errorf("failed to fetch build history: %w", err)}
+		}
+
+		layers := make([]Layer, len(*history))
+		for i, h := range *history {
+			layers[i] = Layer{
+				Created:    h.Created,
+				CreatedBy:  h.CreatedBy,
+				EmptyLayer: h.EmptyLayer,
+			}
+		}
+
+		return buildHistoryLoadedMsg{layers: layers}
+	}
+}
+
+func (m model) buildHistoryView() string {
+	state := m.state.buildHistory
+	title := fmt.Sprintf("Build history of %s (%s)", state.artifact.Name, state.artifact.Hash)
+
+	items := []string{title, state.table.View()}
+	if len(state.data) > 0 {
+		// The table truncates long commands, show the full one below it
+		active := state.data[state.table.Cursor()]
+		items = append(items, infoStyle.Width(140).Render(active.CreatedBy))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, items...)
+}
+
+func (m model) buildHistoryUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case buildHistoryLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.buildHistory = newEmptyBuildHistoryState(m.state.buildHistory, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		m.state.buildHistory = newBuildHistoryState(m.state.buildHistory, msg.layers)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			m.state.buildHistory.cancel()
+			return m.backToArtifacts()
+		}
+	}
+
+	m.state.buildHistory.table, cmd = m.state.buildHistory.table.Update(msg)
+	return m, cmd
+}
+
+var BUILD_HISTORY_COLUMNS = []table.Column{
+	{Title: "Created", Width: 30},
+	{Title: "Empty", Width: 5},
+	{Title: "Command", Width: 100},
+}
+
+// newEmptyBuildHistoryState keeps the request context of state and shows
+// message instead of data.
+func newEmptyBuildHistoryState(state BuildHistoryState, message string) BuildHistoryState {
+	t := table.New(
+		table.WithColumns(BUILD_HISTORY_COLUMNS),
+		table.WithRows([]table.Row{{message, "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []Layer{}
+
+	return state
+}
+
+func newBuildHistoryState(state BuildHistoryState, layers []Layer) BuildHistoryState {
+	rows := make([]table.Row, len(layers))
+	for i, l := range layers {
+		rows[i] = l.ToRow()
+	}
+
+	t := table.New(
+		table.WithColumns(BUILD_HISTORY_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(36),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = layers
+
+	slog.Debug("New Build history state created.")
+
+	return state
+}
+
+// NewBuildHistoryState returns a loading build history state for artifact
+// along with the command that fetches it.
+func (m model) NewBuildHistoryState(artifact Artifact) (BuildHistoryState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := BuildHistoryState{
+		artifact: artifact,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	return newEmptyBuildHistoryState(state, "Loading..."), fetchBuildHistory(ctx, artifact)
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLayerToRow(t *testing.T) {
+	tests := []struct {
+		layer Layer
+		want  []string
+	}{
+		{
+			layer: Layer{Created: "2024-01-01", CreatedBy: "/bin/sh -c #(nop) ADD file:abc in / "},
+			want:  []string{"2024-01-01", "", "ADD file:abc in / "},
+		},
+		{
+			layer: Layer{Created: "2024-01-01", CreatedBy: "/bin/sh -c #(nop)  CMD [\"bash\"]", EmptyLayer: true},
+			want:  []string{"2024-01-01", "yes", ` CMD ["bash"]`},
+		},
+		{
+			layer: Layer{Created: "2024-01-01", CreatedBy: "RUN /bin/sh -c apt-get update # buildkit"},
+			want:  []string{"2024-01-01", "", "RUN /bin/sh -c apt-get update # buildkit"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.layer.ToRow(); !slices.Equal(got, tt.want) {
+			t.Errorf("ToRow() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package tui
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mathiasdonoso/harborw/internal/api/transport"
+)
+
+// httpClient is shared by every harbor and portainer client so retries and
+// rate limits apply to all the requests issued from the TUI.
+var httpClient = transport.NewHttpClientFromEnv()
+
+// probeClient checks whether a service is reachable. It does not retry, an
+// unreachable service is reported at once instead of after every backoff.
+var probeClient = &http.Client{Timeout: 5 * time.Second}
@@ -0,0 +1,9 @@
+package tui
+
+import "context"
+
+// newPageContext returns the context used by every request issued from a
+// page. Cancelling it aborts the fetches and deletions still in flight.
+func newPageContext() (context.Context, context.CancelFunc) {
+	return context.WithCancel(context.Background())
+}
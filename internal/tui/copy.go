@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+// copyTarget is where artifacts are copied to, read from
+// "project/repository[:tag]".
+type copyTarget struct {
+	project string
+	// repository is escaped the way the api expects it
+	repository string
+	tag        string
+}
+
+func (t copyTarget) String() string {
+	target := fmt.Sprintf("%s/%s", t.project, displayName(t.repository))
+	if t.tag != "" {
+		target = fmt.Sprintf("%s:%s", target, t.tag)
+	}
+	return target
+}
+
+func parseCopyTarget(value string) (copyTarget, error) {
+	value = strings.TrimSpace(value)
+	name, tag, _ := strings.Cut(value, ":")
+	project, repository, ok := strings.Cut(name, "/")
+	if !ok || project == "" || repository == "" {
+		return copyTarget{}, fmt.Errorf("invalid target %q, expected project/repository[:tag]", value)
+	}
+
+	return copyTarget{
+		project:    project,
+		repository: escapedRepositoryName(name),
+		tag:        tag,
+	}, nil
+}
+
+type artifactCopiedMsg struct {
+	artifact Artifact
+	target   copyTarget
+	err      error
+}
+
+// copyArtifact copies artifact to target, tagging the copy when target has
+// a tag.
+func copyArtifact(ctx context.Context, artifact Artifact, target copyTarget) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return artifactCopiedMsg{artifact, target, err}
+		}
+
+		err = harborClient.CopyArtifact(ctx, target.project, target.repository, artifact.Project, artifact.Repository, artifact.Hash)
+		if err != nil {
+			return artifactCopiedMsg{artifact, target, err}
+		}
+
+		if target.tag != "" {
+			err = harborClient.CreateTag(ctx, target.project, target.repository, artifact.Hash, target.tag)
+		}
+
+		return artifactCopiedMsg{artifact, target, err}
+	}
+}
+
+// copyArtifacts copies every artifact to target, reporting each one with an
+// artifactCopiedMsg.
+func (m model) copyArtifacts(artifacts []Artifact, value string) (model, tea.Cmd) {
+	target, err := parseCopyTarget(value)
+	if err != nil {
+		m = m.setError(err)
+		return m, nil
+	}
+
+	if target.tag != "" && len(artifacts) > 1 {
+		m = m.setError(fmt.Errorf("cannot give tag %s to %d artifacts, copy them one by one", target.tag, len(artifacts)))
+		return m, nil
+	}
+
+	cmds := []tea.Cmd{}
+	for _, a := range artifacts {
+		cmds = append(cmds, copyArtifact(m.state.artifacts.ctx, a, target))
+	}
+
+	m.state.artifacts.copy = copyProgress{total: len(artifacts)}
+	m = m.setInfo(fmt.Sprintf("Copying %d artifacts to %s", len(artifacts), target))
+
+	return m, tea.Batch(cmds...)
+}
+
+// copyProgress counts the artifacts copied so far and keeps the failures,
+// reported together once every copy ended.
+type copyProgress struct {
+	total    int
+	done     int
+	failures []string
+}
+
+// record counts the copy of artifact, failed when err is not nil.
+func (p copyProgress) record(artifact Artifact, err error) copyProgress {
+	p.done++
+	if err != nil {
+		p.failures = append(p.failures, fmt.Sprintf("%s (%v)", artifact.Name, err))
+	}
+	return p
+}
+
+func (p copyProgress) finished() bool {
+	return p.done == p.total
+}
+
+// summary describes the copies to target so far, listing every failed one.
+func (p copyProgress) summary(target copyTarget) (string, error) {
+	if !p.finished() {
+		message := fmt.Sprintf("[%d/%d] Copying to %s", p.done, p.total, target)
+		if len(p.failures) > 0 {
+			message = fmt.Sprintf("%s, %d failed so far", message, len(p.failures))
+		}
+		return message, nil
+	}
+
+	if len(p.failures) > 0 {
+		return "", fmt.Errorf("%d of %d copies to %s failed: %s", len(p.failures), p.total, target, strings.Join(p.failures, "; "))
+	}
+	return fmt.Sprintf("Copied %d artifacts to %s", p.total, target), nil
+}
+
+func (m model) artifactCopiedUpdate(msg artifactCopiedMsg) (model, tea.Cmd) {
+	if errors.Is(msg.err, context.Canceled) {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		slog.Error("Error copying artifact", "artifact", msg.artifact.Hash, "err", msg.err)
+	}
+	progress := m.state.artifacts.copy.record(msg.artifact, msg.err)
+	m.state.artifacts.copy = progress
+
+	message, err := progress.summary(msg.target)
+	if err != nil {
+		m = m.setError(err)
+	} else {
+		m = m.setInfo(message)
+	}
+
+	// Copying into the same repository adds tags to the listed artifacts
+	state := m.state.artifacts
+	if progress.finished() && msg.target.project == state.project && msg.target.repository == state.repository {
+		return m, state.fetch()
+	}
+
+	return m, nil
+}
+
+// copyTargetSuggestions proposes the current repository in every known
+// project.
+func (m model) copyTargetSuggestions() []string {
+	repository := displayName(m.state.artifacts.repository)
+	suggestions := []string{}
+	for _, p := range m.state.projects.data {
+		suggestions = append(suggestions, fmt.Sprintf("%s/%s", p.Name, repository))
+	}
+	return suggestions
+}
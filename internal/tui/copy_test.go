@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseCopyTarget(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    copyTarget
+		wantErr bool
+	}{
+		{value: "library/nginx", want: copyTarget{project: "library", repository: "nginx"}},
+		{value: " library/nginx:1.25 ", want: copyTarget{project: "library", repository: "nginx", tag: "1.25"}},
+		{value: "team/apps/web:latest", want: copyTarget{project: "team", repository: "apps%252Fweb", tag: "latest"}},
+		{value: "library/nginx:", want: copyTarget{project: "library", repository: "nginx"}},
+		{value: "nginx", wantErr: true},
+		{value: "nginx:1.25", wantErr: true},
+		{value: "/nginx", wantErr: true},
+		{value: "library/", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseCopyTarget(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCopyTarget(%q) error = %v, wantErr %t", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseCopyTarget(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyProgressSummary(t *testing.T) {
+	target := copyTarget{project: "backup", repository: "nginx"}
+	failure := errors.New("forbidden")
+
+	tests := []struct {
+		name string
+		// results are the errors of the copies finished so far, in order
+		results     []error
+		total       int
+		wantMessage string
+		wantErr     []string
+	}{
+		{
+			name:        "in progress",
+			results:     []error{nil},
+			total:       3,
+			wantMessage: "[1/3] Copying to backup/nginx",
+		},
+		{
+			name:        "in progress with a failure",
+			results:     []error{failure, nil},
+			total:       3,
+			wantMessage: "[2/3] Copying to backup/nginx, 1 failed so far",
+		},
+		{
+			name:        "all copied",
+			results:     []error{nil, nil},
+			total:       2,
+			wantMessage: "Copied 2 artifacts to backup/nginx",
+		},
+		{
+			name: "early failure kept until the end",
+			// The first failure must not be replaced by the later successes
+			results: []error{failure, nil, nil, errors.New("not found")},
+			total:   4,
+			wantErr: []string{"2 of 4 copies to backup/nginx failed", "a0 (forbidden)", "a3 (not found)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := copyProgress{total: tt.total}
+			for i, err := range tt.results {
+				progress = progress.record(Artifact{Name: "a" + string(rune('0'+i))}, err)
+			}
+
+			message, err := progress.summary(target)
+			if len(tt.wantErr) == 0 {
+				if err != nil || message != tt.wantMessage {
+					t.Errorf("summary() = %q, %v, want %q", message, err, tt.wantMessage)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("summary() error = nil, want the failed copies")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("summary() error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -1,5 +1,36 @@
 package tui
 
+type FooterState struct {
+	message string
+	isError bool
+}
+
 func (m model) footerView() string {
-	return ""
+	footer := m.state.footer
+	if footer.message == "" {
+		return ""
+	}
+
+	if footer.isError {
+		return errorStyle.Render(footer.message)
+	}
+
+	return infoStyle.Render(footer.message)
+}
+
+// setError shows err in the footer until another message replaces it.
+func (m model) setError(err error) model {
+	m.state.footer = FooterState{
+		message: err.Error(),
+		isError: true,
+	}
+	return m
+}
+
+// setInfo shows message in the footer until another message replaces it.
+func (m model) setInfo(message string) model {
+	m.state.footer = FooterState{
+		message: message,
+	}
+	return m
 }
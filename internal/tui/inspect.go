@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/portainer"
+)
+
+type InspectState struct {
+	viewport   viewport.Model
+	artifact   Artifact
+	platforms  []Artifact
+	containers []portainer.ContainersResult
+	// containersStatus explains why no container is listed
+	containersStatus string
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+type containersLoadedMsg struct {
+	containers []portainer.ContainersResult
+	err        error
+}
+
+func fetchContainers(ctx context.Context, hash string) tea.Cmd {
+	return func() tea.Msg {
+		containers, err := FindContainers(ctx, hash)
+		return containersLoadedMsg{containers, err}
+	}
+}
+
+var sectionTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("57"))
+
+// section renders a titled block of lines, or "-" when there are none.
+func section(title string, lines []string) string {
+	if len(lines) == 0 {
+		lines = []string{"-"}
+	}
+	return sectionTitleStyle.Render(title) + "\n  " + strings.Join(lines, "\n  ") + "\n"
+}
+
+// keyValues renders every entry of values as "key=value", sorted by key.
+func keyValues(values map[string]string) []string {
+	lines := []string{}
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		lines = append(lines, fmt.Sprintf("%s=%s", k, values[k]))
+	}
+	return lines
+}
+
+func (s InspectState) content() string {
+	a := s.artifact
+	attrs := a.Attributes
+	config := attrs.Config
+
+	sections := []string{
+		section("Artifact", []string{
+			fmt.Sprintf("Tags: %s", strings.Join(a.Tags, ", ")),
+			fmt.Sprintf("Digest: %s", a.Hash),
+			fmt.Sprintf("Media type: %s", a.MediaType),
+			fmt.Sprintf("Platform: %s/%s", attrs.Os, attrs.Architecture),
+			fmt.Sprintf("Author: %s", attrs.Author),
+			fmt.Sprintf("Created: %s", attrs.Created),
+		}),
+	}
+
+	if a.Parent != "" {
+		sections = append(sections, section("Index", []string{a.Parent}))
+	}
+
+	if len(s.platforms) > 0 {
+		lines := []string{}
+		for _, p := range s.platforms {
+			lines = append(lines, fmt.Sprintf("%-20s %-75s %.2f MiB", p.Platform, p.Hash, p.Size/1024/1024))
+		}
+		sections = append(sections, section("Platforms", lines))
+	}
+
+	sections = append(sections,
+		section("Entrypoint", config.Entrypoint),
+		section("Cmd", config.Cmd),
+		section("Working dir / User / Stop signal", []string{
+			fmt.Sprintf("%s / %s / %s", config.Workingdir, config.User, config.Stopsignal),
+		}),
+		section("Environment", config.Env),
+		section("Exposed ports", slices.Sorted(maps.Keys(config.Exposedports))),
+		section("Volumes", slices.Sorted(maps.Keys(config.Volumes))),
+		section("Labels", keyValues(config.Labels)),
+	)
+
+	if s.containersStatus != "" {
+		sections = append(sections, section("Containers", []string{s.containersStatus}))
+	}
+
+	for _, c := range s.containers {
+		ports := []string{}
+		for _, p := range c.Ports {
+			ports = append(ports, fmt.Sprintf("%s:%d->%d/%s", p.Ip, p.PublicPort, p.PrivatePort, p.Type))
+		}
+
+		networks := []string{}
+		for _, name := range slices.Sorted(maps.Keys(c.Networksettings.Networks)) {
+			networks = append(networks, fmt.Sprintf("%s (%s)", name, c.Networksettings.Networks[name].Ipaddress))
+		}
+
+		lines := []string{
+			fmt.Sprintf("Id: %s", c.Id),
+			fmt.Sprintf("Image: %s", c.Image),
+			fmt.Sprintf("State: %s (%s)", c.State, c.Status),
+			fmt.Sprintf("Ports: %s", strings.Join(ports, ", ")),
+			fmt.Sprintf("Networks: %s", strings.Join(networks, ", ")),
+			"Labels:",
+		}
+		for _, l := range keyValues(c.Labels) {
+			lines = append(lines, "  "+l)
+		}
+
+		sections = append(sections, section("Container "+strings.Join(c.Names, ", "), lines))
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+func (m model) inspectView() string {
+	return m.state.inspect.viewport.View()
+}
+
+func (m model) inspectUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case containersLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.inspect.containersStatus = fmt.Sprintf("Could not search containers: %s", msg.err)
+		} else if len(msg.containers) == 0 {
+			m.state.inspect.containersStatus = "Not running in any container"
+		} else {
+			m.state.inspect.containersStatus = ""
+		}
+
+		m.state.inspect.containers = msg.containers
+		m.state.inspect.viewport.SetContent(m.state.inspect.content())
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			m.state.inspect.cancel()
+			return m.backToArtifacts()
+		}
+	}
+
+	m.state.inspect.viewport, cmd = m.state.inspect.viewport.Update(msg)
+	return m, cmd
+}
+
+// NewInspectState returns the detail state of artifact, listing platforms
+// when it is an index, along with the command searching the containers
+// running it.
+func (m model) NewInspectState(artifact Artifact, platforms []Artifact) (InspectState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := InspectState{
+		viewport:         viewport.New(140, 40),
+		artifact:         artifact,
+		platforms:        platforms,
+		containersStatus: "Searching containers...",
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+	state.viewport.SetContent(state.content())
+
+	return state, fetchContainers(ctx, artifact.Hash)
+}
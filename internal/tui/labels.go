@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+type labelsLoadedMsg struct {
+	labels []harbor.Label
+	err    error
+}
+
+type artifactsLabelMsg struct {
+	action    promptAction
+	label     harbor.Label
+	artifacts []Artifact
+	err       error
+}
+
+// fetchLabels loads the global labels and the labels of project, the ones
+// that can be attached to its artifacts.
+func fetchLabels(ctx context.Context, project string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return labelsLoadedMsg{err: err}
+		}
+
+		global, err := harborClient.FetchLabels(ctx, harbor.LabelScopeGlobal, 0)
+		if err != nil {
+			return labelsLoadedMsg{err: fmt.Errorf("failed to fetch labels: %w", err)}
+		}
+
+		p, err := harborClient.FetchProject(ctx, project)
+		if err != nil {
+			return labelsLoadedMsg{err: fmt.Errorf("failed to fetch labels: %w", err)}
+		}
+
+		projectLabels, err := harborClient.FetchLabels(ctx, harbor.LabelScopeProject, p.ProjectId)
+		if err != nil {
+			return labelsLoadedMsg{err: fmt.Errorf("failed to fetch labels: %w", err)}
+		}
+
+		return labelsLoadedMsg{labels: append(*global, *projectLabels...)}
+	}
+}
+
+// updateArtifactsLabel attaches, or detaches depending on action, label to
+// every artifact.
+func updateArtifactsLabel(ctx context.Context, action promptAction, artifacts []Artifact, label harbor.Label) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return artifactsLabelMsg{action, label, artifacts, err}
+		}
+
+		errs := []error{}
+		for _, a := range artifacts {
+			if action == addLabelPromptAction {
+				err = harborClient.AddArtifactLabel(ctx, a.Project, a.Repository, a.Hash, label.Id)
+			} else {
+				err = harborClient.RemoveArtifactLabel(ctx, a.Project, a.Repository, a.Hash, label.Id)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", a.Name, err))
+			}
+		}
+
+		return artifactsLabelMsg{action, label, artifacts, errors.Join(errs...)}
+	}
+}
+
+func findLabel(labels []harbor.Label, name string) (harbor.Label, bool) {
+	for _, l := range labels {
+		if l.Name == name {
+			return l, true
+		}
+	}
+	return harbor.Label{}, false
+}
+
+func labelNames(labels []harbor.Label) []string {
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// colorLabelsColumn draws the labels of every row of the rendered artifacts
+// table with their colors, truncated to the width of the column. Table cells
+// cannot hold styled text, the table counts escape codes as characters, so
+// the plain names it rendered are replaced afterwards. Rows are recognized by
+// the digest shown in their sha256 column.
+func colorLabelsColumn(view string, artifacts []Artifact) string {
+	cell := GetTableDefaultStyles().Cell
+	start := func(column int) int {
+		offset := cell.GetPaddingLeft()
+		for _, c := range ARTIFACTS_COLUMNS[:column] {
+			offset += c.Width + cell.GetHorizontalPadding()
+		}
+		return offset
+	}
+	hashStart, hashWidth := start(artifactsHashColumn), ARTIFACTS_COLUMNS[artifactsHashColumn].Width
+	labelsStart, labelsWidth := start(artifactsLabelsColumn), ARTIFACTS_COLUMNS[artifactsLabelsColumn].Width
+
+	byHash := map[string]Artifact{}
+	for _, a := range artifacts {
+		if len(a.Labels) > 0 {
+			byHash[ansi.Truncate(a.Hash, hashWidth, "…")] = a
+		}
+	}
+
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		if ansi.StringWidth(plain) < labelsStart+labelsWidth {
+			continue
+		}
+		hash := strings.TrimSpace(ansi.Cut(plain, hashStart, hashStart+hashWidth))
+		a, ok := byHash[hash]
+		if !ok {
+			continue
+		}
+
+		labels := ansi.Truncate(renderLabels(a.Labels), labelsWidth, "…")
+		labels += strings.Repeat(" ", labelsWidth-ansi.StringWidth(labels))
+		lines[i] = ansi.Cut(line, 0, labelsStart) + labels + ansi.Cut(line, labelsStart+labelsWidth, ansi.StringWidth(line))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderLabels draws every label with the color configured in harbor.
+func renderLabels(labels []harbor.Label) string {
+	chips := make([]string, len(labels))
+	for i, l := range labels {
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if l.Color != "" {
+			style = style.Background(lipgloss.Color(l.Color)).Foreground(contrastColor(l.Color))
+		}
+		chips[i] = style.Render(l.Name)
+	}
+	return strings.Join(chips, " ")
+}
+
+// contrastColor returns black or white, whichever reads better on top of
+// the hex color background.
+func contrastColor(background string) lipgloss.Color {
+	var r, g, b int
+	if _, err := fmt.Sscanf(background, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return lipgloss.Color("15")
+	}
+
+	if r*299+g*587+b*114 > 128000 {
+		return lipgloss.Color("0")
+	}
+	return lipgloss.Color("15")
+}
+
+func (m model) labelUpdate(msg tea.Msg) (model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case labelsLoadedMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				slog.Error("Error fetching labels", "err", msg.err)
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		m.state.artifacts.labels = msg.labels
+	case artifactsLabelMsg:
+		verb := "attach"
+		if msg.action == removeLabelPromptAction {
+			verb = "detach"
+		}
+
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			slog.Error("Error updating labels", "err", msg.err)
+			m = m.setError(fmt.Errorf("failed to %s label %s: %w", verb, msg.label.Name, msg.err))
+		} else {
+			m = m.setInfo(fmt.Sprintf("Label %s %sed on %d artifacts", msg.label.Name, verb, len(msg.artifacts)))
+		}
+
+		return m, m.state.artifacts.fetch()
+	}
+
+	return m, nil
+}
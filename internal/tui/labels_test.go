@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestContrastColor(t *testing.T) {
+	tests := []struct {
+		background string
+		want       lipgloss.Color
+	}{
+		{background: "#ffffff", want: "0"},
+		{background: "#ffff00", want: "0"},
+		{background: "#000000", want: "15"},
+		{background: "#0000ff", want: "15"},
+		{background: "red", want: "15"},
+		{background: "", want: "15"},
+	}
+
+	for _, tt := range tests {
+		if got := contrastColor(tt.background); got != tt.want {
+			t.Errorf("contrastColor(%q) = %q, want %q", tt.background, got, tt.want)
+		}
+	}
+}
+
+func TestColorLabelsColumn(t *testing.T) {
+	artifacts := []Artifact{
+		{Hash: "sha256:labelled", Labels: []harbor.Label{{Name: "prod", Color: "#00ff00"}}},
+		{Hash: "sha256:plain"},
+	}
+	view := newArtifactsState(ArtifactsState{}, artifacts).table.View()
+
+	colored := colorLabelsColumn(view, artifacts)
+	viewLines, coloredLines := strings.Split(view, "\n"), strings.Split(colored, "\n")
+	if len(coloredLines) != len(viewLines) {
+		t.Fatalf("colorLabelsColumn() returned %d lines, want %d", len(coloredLines), len(viewLines))
+	}
+
+	cell := GetTableDefaultStyles().Cell
+	start := cell.GetPaddingLeft()
+	for _, c := range ARTIFACTS_COLUMNS[:artifactsLabelsColumn] {
+		start += c.Width + cell.GetHorizontalPadding()
+	}
+	width := ARTIFACTS_COLUMNS[artifactsLabelsColumn].Width
+
+	found := false
+	for i, line := range coloredLines {
+		plain := ansi.Strip(line)
+		if ansi.StringWidth(plain) != ansi.StringWidth(ansi.Strip(viewLines[i])) {
+			t.Errorf("line %d changed width: %q", i, plain)
+		}
+		if strings.Contains(plain, "sha256:labelled") {
+			found = true
+			if got := strings.TrimSpace(ansi.Cut(plain, start, start+width)); got != "prod" {
+				t.Errorf("labels column = %q, want prod", got)
+			}
+		}
+		if strings.Contains(plain, "sha256:plain") && line != viewLines[i] {
+			t.Errorf("row without labels changed: %q", line)
+		}
+	}
+	if !found {
+		t.Fatal("labelled artifact not in the view")
+	}
+}
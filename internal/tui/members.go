@@ -0,0 +1,350 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+// memberRoles maps the role names typed in prompts to their ids.
+var memberRoles = map[string]int{
+	"admin":         harbor.RoleProjectAdmin,
+	"maintainer":    harbor.RoleMaintainer,
+	"developer":     harbor.RoleDeveloper,
+	"guest":         harbor.RoleGuest,
+	"limited-guest": harbor.RoleLimitedGuest,
+}
+
+var memberRoleNames = []string{"admin", "maintainer", "developer", "guest", "limited-guest"}
+
+func parseMemberRole(name string) (int, error) {
+	role, ok := memberRoles[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown role %q, use one of %s", name, strings.Join(memberRoleNames, ", "))
+	}
+	return role, nil
+}
+
+func memberRow(member harbor.MemberResult) []string {
+	kind := "user"
+	if member.EntityType == harbor.MemberTypeGroup {
+		kind = "group"
+	}
+
+	return []string{
+		member.EntityName,
+		kind,
+		member.RoleName,
+	}
+}
+
+type MembersState struct {
+	table   table.Model
+	data    []harbor.MemberResult
+	project Project
+	// sysadmin is set when the current user administers harbor itself
+	sysadmin bool
+	prompt   PromptState
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+type membersLoadedMsg struct {
+	members  []harbor.MemberResult
+	sysadmin bool
+	err      error
+}
+
+// currentUserIsSysadmin reports whether the current user administers harbor,
+// and so every project. A failure only logs, the user is then treated as a
+// regular one and harbor still refuses what they may not do.
+func currentUserIsSysadmin(ctx context.Context) bool {
+	harborClient, err := harbor.NewHarborApiClient(httpClient)
+	if err != nil {
+		return false
+	}
+
+	user, err := harborClient.FetchCurrentUser(ctx)
+	if err != nil {
+		slog.Error("Error fetching current user", "err", err)
+		return false
+	}
+
+	return user.SysadminFlag
+}
+
+type memberUpdatedMsg struct {
+	message string
+	err     error
+}
+
+func fetchMembers(ctx context.Context, project string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return membersLoadedMsg{err: err}
+		}
+
+		r, err := harborClient.FetchMembers(ctx, project)
+		if err != nil {
+			slog.Error("Error fetching members", "err", err)
+			return membersLoadedMsg{err: fmt.Errorf("failed to fetch members of %s: %w", project, err)}
+		}
+
+		return membersLoadedMsg{members: *r, sysadmin: currentUserIsSysadmin(ctx)}
+	}
+}
+
+// addMember reads "name role" or "group:<ldap dn> role" and adds that user,
+// or LDAP group, to project.
+func addMember(ctx context.Context, project string, value string) tea.Cmd {
+	return func() tea.Msg {
+		separator := strings.LastIndex(value, " ")
+		if separator < 0 {
+			return memberUpdatedMsg{err: fmt.Errorf("invalid member %q, expected name role", value)}
+		}
+		name, roleName := strings.TrimSpace(value[:separator]), value[separator+1:]
+
+		role, err := parseMemberRole(roleName)
+		if err != nil {
+			return memberUpdatedMsg{err: err}
+		}
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return memberUpdatedMsg{err: err}
+		}
+
+		if dn, ok := strings.CutPrefix(name, "group:"); ok {
+			err = harborClient.AddLdapGroupMember(ctx, project, dn, role)
+		} else {
+			err = harborClient.AddUserMember(ctx, project, name, role)
+		}
+		if err != nil {
+			slog.Error("Error adding member", "err", err)
+			return memberUpdatedMsg{err: fmt.Errorf("failed to add %s to %s: %w", name, project, err)}
+		}
+
+		return memberUpdatedMsg{message: fmt.Sprintf("Added %s to %s as %s", name, project, roleName)}
+	}
+}
+
+func updateMemberRole(ctx context.Context, project string, member harbor.MemberResult, roleName string) tea.Cmd {
+	return func() tea.Msg {
+		role, err := parseMemberRole(roleName)
+		if err != nil {
+			return memberUpdatedMsg{err: err}
+		}
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return memberUpdatedMsg{err: err}
+		}
+
+		if err := harborClient.UpdateMemberRole(ctx, project, member.Id, role); err != nil {
+			slog.Error("Error updating member role", "err", err)
+			return memberUpdatedMsg{err: fmt.Errorf("failed to change the role of %s: %w", member.EntityName, err)}
+		}
+
+		return memberUpdatedMsg{message: fmt.Sprintf("%s is now %s of %s", member.EntityName, roleName, project)}
+	}
+}
+
+func deleteMember(ctx context.Context, project string, member harbor.MemberResult) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return memberUpdatedMsg{err: err}
+		}
+
+		if err := harborClient.DeleteMember(ctx, project, member.Id); err != nil {
+			slog.Error("Error removing member", "err", err)
+			return memberUpdatedMsg{err: fmt.Errorf("failed to remove %s from %s: %w", member.EntityName, project, err)}
+		}
+
+		return memberUpdatedMsg{message: fmt.Sprintf("Removed %s from %s", member.EntityName, project)}
+	}
+}
+
+// editable reports whether the current user administers the project, only
+// project administrators and harbor administrators may change its members.
+func (s MembersState) editable() bool {
+	return s.sysadmin || s.project.RoleId == harbor.RoleProjectAdmin
+}
+
+func (m model) membersView() string {
+	state := m.state.members
+	title := fmt.Sprintf("Members of %s", state.project.Name)
+	items := []string{sectionTitleStyle.Render(title), state.table.View()}
+	if !state.editable() {
+		items = append(items, infoStyle.Render("Read only, only project and harbor administrators can change members"))
+	}
+
+	return withPrompt(lipgloss.JoinVertical(lipgloss.Left, items...), state.prompt)
+}
+
+func (m model) membersUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	state := m.state.members
+	if state.prompt.handles(msg) {
+		action := state.prompt.action
+		prompt, value, submitted, cmd := state.prompt.update(msg)
+		m.state.members.prompt = prompt
+		if !submitted || value == "" {
+			return m, cmd
+		}
+
+		switch action {
+		case addMemberPromptAction:
+			return m, addMember(state.ctx, state.project.Name, value)
+		case memberRolePromptAction, deleteMemberPromptAction:
+			if len(state.data) == 0 {
+				return m, nil
+			}
+			active := state.data[state.table.Cursor()]
+			if action == memberRolePromptAction {
+				return m, updateMemberRole(state.ctx, state.project.Name, active, value)
+			}
+			if value != "yes" {
+				return m, nil
+			}
+			return m, deleteMember(state.ctx, state.project.Name, active)
+		}
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case membersLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.members = newEmptyMembersState(state, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		state.sysadmin = msg.sysadmin
+		m.state.members = newMembersState(state, msg.members)
+		return m, nil
+	case memberUpdatedMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		m = m.setInfo(msg.message)
+		m.state.members = newEmptyMembersState(state, "Loading...")
+		return m, fetchMembers(state.ctx, state.project.Name)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			state.cancel()
+			m = m.SwitchPage(projectsPage)
+			return m, nil
+		case "n", "r", "D":
+			if !state.editable() {
+				m = m.setError(fmt.Errorf("only administrators of %s can change its members", state.project.Name))
+				return m, nil
+			}
+		}
+
+		switch msg.String() {
+		case "n":
+			// Add a user or an LDAP group
+			m.state.members.prompt, cmd = newPrompt("Add member (user role, or group:<ldap dn> role)", "", addMemberPromptAction)
+			return m, cmd
+		case "r":
+			// Change the role of the member
+			if len(state.data) == 0 {
+				return m, nil
+			}
+			active := state.data[state.table.Cursor()]
+			m.state.members.prompt, cmd = newPrompt(fmt.Sprintf("New role of %s", active.EntityName), "", memberRolePromptAction)
+			m.state.members.prompt = m.state.members.prompt.withSuggestions(memberRoleNames)
+			return m, cmd
+		case "D":
+			// Remove the member from the project
+			if len(state.data) == 0 {
+				return m, nil
+			}
+			active := state.data[state.table.Cursor()]
+			label := fmt.Sprintf("Remove %s from %s? Type yes to confirm", active.EntityName, state.project.Name)
+			m.state.members.prompt, cmd = newPrompt(label, "", deleteMemberPromptAction)
+			return m, cmd
+		}
+	}
+
+	m.state.members.table, cmd = state.table.Update(msg)
+	return m, cmd
+}
+
+var MEMBERS_COLUMNS = []table.Column{
+	{Title: "Name", Width: 50},
+	{Title: "Type", Width: 8},
+	{Title: "Role", Width: 16},
+}
+
+// newEmptyMembersState keeps the request context of state and shows message
+// instead of data.
+func newEmptyMembersState(state MembersState, message string) MembersState {
+	t := table.New(
+		table.WithColumns(MEMBERS_COLUMNS),
+		table.WithRows([]table.Row{{message, "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []harbor.MemberResult{}
+
+	return state
+}
+
+func newMembersState(state MembersState, members []harbor.MemberResult) MembersState {
+	rows := make([]table.Row, len(members))
+	for i, member := range members {
+		rows[i] = memberRow(member)
+	}
+
+	t := table.New(
+		table.WithColumns(MEMBERS_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = members
+
+	slog.Debug("New Members state created.")
+
+	return state
+}
+
+// NewMembersState returns a loading state with the members of project along
+// with the command that fetches them.
+func (m model) NewMembersState(project Project) (MembersState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := MembersState{
+		project: project,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	return newEmptyMembersState(state, "Loading..."), fetchMembers(ctx, project.Name)
+}
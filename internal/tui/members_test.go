@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestParseMemberRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+	}{
+		{name: "admin", want: harbor.RoleProjectAdmin},
+		{name: "maintainer", want: harbor.RoleMaintainer},
+		{name: "developer", want: harbor.RoleDeveloper},
+		{name: "guest", want: harbor.RoleGuest},
+		{name: "limited-guest", want: harbor.RoleLimitedGuest},
+		{name: "Developer", want: harbor.RoleDeveloper},
+		{name: "GUEST", want: harbor.RoleGuest},
+		{name: "", wantErr: true},
+		{name: "owner", wantErr: true},
+		{name: "limited guest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMemberRole(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMemberRole(%q) error = %v, wantErr %t", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseMemberRole(%q) = %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberRoleNames(t *testing.T) {
+	// Every suggested role must be accepted
+	for _, name := range memberRoleNames {
+		if _, err := parseMemberRole(name); err != nil {
+			t.Errorf("parseMemberRole(%q) error = %v", name, err)
+		}
+	}
+	if len(memberRoleNames) != len(memberRoles) {
+		t.Errorf("%d role names suggested, want %d", len(memberRoleNames), len(memberRoles))
+	}
+}
+
+func TestMembersEditable(t *testing.T) {
+	tests := []struct {
+		name     string
+		roleId   int
+		sysadmin bool
+		want     bool
+	}{
+		{name: "project admin", roleId: harbor.RoleProjectAdmin, want: true},
+		{name: "maintainer", roleId: harbor.RoleMaintainer, want: false},
+		{name: "not a member", roleId: 0, want: false},
+		{name: "harbor admin not a member", roleId: 0, sysadmin: true, want: true},
+		{name: "harbor admin and guest", roleId: harbor.RoleGuest, sysadmin: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := MembersState{project: Project{Name: "library", RoleId: tt.roleId}, sysadmin: tt.sysadmin}
+			if got := state.editable(); got != tt.want {
+				t.Errorf("editable() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
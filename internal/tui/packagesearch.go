@@ -0,0 +1,235 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+type PackageMatch struct {
+	Repository string
+	Artifact   Artifact
+	Package    Package
+}
+
+func (p PackageMatch) ToRow() []string {
+	return []string{
+		displayName(p.Repository),
+		strings.Join(p.Artifact.Tags, ", "),
+		p.Artifact.Hash,
+		p.Package.Name,
+		p.Package.Version,
+	}
+}
+
+type PackageSearchState struct {
+	table table.Model
+	data  []PackageMatch
+	// query is the package searched, as name or name@version
+	query  string
+	scope  string
+	from   page
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type packageSearchMsg struct {
+	matches  []PackageMatch
+	searched int
+	err      error
+}
+
+// parsePackageQuery splits "name@version" into its parts, version is
+// optional. A leading @ belongs to the name, as in scoped npm packages.
+func parsePackageQuery(query string) (string, string) {
+	query = strings.TrimSpace(query)
+	if i := strings.LastIndex(query, "@"); i > 0 {
+		return query[:i], query[i+1:]
+	}
+	return query, ""
+}
+
+// searchPackage looks for the package in the SBOM of every artifact of the
+// repositories of project. Nil repositories stands for every repository of
+// the project, whatever the repositories page currently shows.
+func searchPackage(ctx context.Context, project string, repositories []string, query string) tea.Cmd {
+	return func() tea.Msg {
+		name, version := parsePackageQuery(query)
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return packageSearchMsg{err: err}
+		}
+
+		if repositories == nil {
+			r, err := harborClient.FetchRepositories(ctx, project, harbor.QueryOptions{})
+			if err != nil {
+				return packageSearchMsg{err: fmt.Errorf("failed to fetch repositories: %w", err)}
+			}
+			for _, repo := range *r {
+				repositories = append(repositories, escapedRepositoryName(repo.Name))
+			}
+		}
+
+		matches := []PackageMatch{}
+		searched := 0
+		errs := []error{}
+		for _, repository := range repositories {
+			artifacts, err := harborClient.FetchArtifacts(ctx, project, repository, harbor.QueryOptions{})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", repository, err))
+				continue
+			}
+
+			for _, ar := range *artifacts {
+				artifact := newArtifact(project, repository, ar)
+				accessory, ok := sbomAccessory(artifact)
+				if !ok {
+					continue
+				}
+
+				sbom, err := harborClient.FetchSbom(ctx, project, repository, accessory)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s@%s: %w", repository, ar.Digest, err))
+					continue
+				}
+				searched++
+
+				for _, p := range newPackages(sbom) {
+					if strings.EqualFold(p.Name, name) && (version == "" || p.Version == version) {
+						matches = append(matches, PackageMatch{repository, artifact, p})
+					}
+				}
+			}
+
+			if ctx.Err() != nil {
+				return packageSearchMsg{err: ctx.Err()}
+			}
+		}
+
+		slog.Debug(fmt.Sprintf("Searched %s in %d SBOMs", query, searched), "matches", len(matches))
+
+		return packageSearchMsg{matches, searched, errors.Join(errs...)}
+	}
+}
+
+func (m model) packageSearchView() string {
+	state := m.state.packageSearch
+	title := fmt.Sprintf("Images of %s containing %s", state.scope, state.query)
+	return lipgloss.JoinVertical(lipgloss.Left, title, state.table.View())
+}
+
+func (m model) packageSearchUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case packageSearchMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+
+		if msg.err != nil {
+			slog.Error("Error searching package", "err", msg.err)
+			m = m.setError(fmt.Errorf("some SBOMs could not be searched: %w", msg.err))
+		} else {
+			m = m.setInfo(fmt.Sprintf("Searched %d SBOMs", msg.searched))
+		}
+
+		m.state.packageSearch = newPackageSearchState(m.state.packageSearch, msg.matches)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			m.state.packageSearch.cancel()
+			if m.state.packageSearch.from == artifactsPage {
+				return m.backToArtifacts()
+			}
+			m = m.SwitchPage(m.state.packageSearch.from)
+			return m, nil
+		}
+	}
+
+	m.state.packageSearch.table, cmd = m.state.packageSearch.table.Update(msg)
+	return m, cmd
+}
+
+var PACKAGE_SEARCH_COLUMNS = []table.Column{
+	{Title: "Repository", Width: 30},
+	{Title: "Tags", Width: 25},
+	{Title: "sha256", Width: 15},
+	{Title: "Package", Width: 30},
+	{Title: "Version", Width: 20},
+}
+
+// newEmptyPackageSearchState keeps the request context of state and shows
+// message instead of data.
+func newEmptyPackageSearchState(state PackageSearchState, message string) PackageSearchState {
+	t := table.New(
+		table.WithColumns(PACKAGE_SEARCH_COLUMNS),
+		table.WithRows([]table.Row{{message, "", "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []PackageMatch{}
+
+	return state
+}
+
+func newPackageSearchState(state PackageSearchState, matches []PackageMatch) PackageSearchState {
+	if len(matches) == 0 {
+		return newEmptyPackageSearchState(state, "No image contains the package")
+	}
+
+	rows := make([]table.Row, len(matches))
+	for i, p := range matches {
+		rows[i] = p.ToRow()
+	}
+
+	t := table.New(
+		table.WithColumns(PACKAGE_SEARCH_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(38),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = matches
+
+	slog.Debug("New Package search state created.")
+
+	return state
+}
+
+// NewPackageSearchState returns a searching state for the package query in
+// the given repositories of project, or in all of them when nil, along with
+// the command running the search. from is the page to return to.
+func (m model) NewPackageSearchState(project string, repositories []string, query string, from page) (PackageSearchState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	scope := project
+	if len(repositories) == 1 {
+		scope = fmt.Sprintf("%s/%s", project, displayName(repositories[0]))
+	}
+
+	state := PackageSearchState{
+		query:  query,
+		scope:  scope,
+		from:   from,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	return newEmptyPackageSearchState(state, "Searching..."), searchPackage(ctx, project, repositories, query)
+}
@@ -0,0 +1,25 @@
+package tui
+
+import "testing"
+
+func TestParsePackageQuery(t *testing.T) {
+	tests := []struct {
+		query       string
+		wantName    string
+		wantVersion string
+	}{
+		{query: "openssl", wantName: "openssl"},
+		{query: "openssl@3.0.13", wantName: "openssl", wantVersion: "3.0.13"},
+		{query: "  lodash@4.17.21 ", wantName: "lodash", wantVersion: "4.17.21"},
+		{query: "@babel/core", wantName: "@babel/core"},
+		{query: "@babel/core@7.24.0", wantName: "@babel/core", wantVersion: "7.24.0"},
+		{query: "", wantName: ""},
+	}
+
+	for _, tt := range tests {
+		name, version := parsePackageQuery(tt.query)
+		if name != tt.wantName || version != tt.wantVersion {
+			t.Errorf("parsePackageQuery(%q) = %q, %q, want %q, %q", tt.query, name, version, tt.wantName, tt.wantVersion)
+		}
+	}
+}
@@ -0,0 +1,439 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+// projectField identifies a field of the project form.
+type projectField int
+
+const (
+	nameField projectField = iota
+	publicField
+	autoScanField
+	preventVulField
+	severityField
+	quotaField
+)
+
+var focusedFieldStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+
+type ProjectFormState struct {
+	// project is empty when creating a new project
+	project    string
+	loading    bool
+	saving     bool
+	name       textinput.Model
+	quota      textinput.Model
+	public     bool
+	autoScan   bool
+	preventVul bool
+	severity   int
+	// quotaId is 0 when the quota of the project could not be read
+	quotaId int
+	limit   int64
+	focus   projectField
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+type projectFormLoadedMsg struct {
+	project *harbor.ProjectsResult
+	quota   *harbor.QuotaResult
+	err     error
+}
+
+type projectSavedMsg struct {
+	project string
+	err     error
+}
+
+func fetchProjectForm(ctx context.Context, project string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return projectFormLoadedMsg{err: err}
+		}
+
+		p, err := harborClient.FetchProject(ctx, project)
+		if err != nil {
+			return projectFormLoadedMsg{err: fmt.Errorf("failed to fetch project %s: %w", project, err)}
+		}
+
+		quota, err := harborClient.FetchProjectQuota(ctx, p.ProjectId)
+		if err != nil {
+			// Only administrators can change quotas
+			slog.Debug("Could not fetch project quota", "project", project, "err", err)
+			return projectFormLoadedMsg{project: p}
+		}
+
+		return projectFormLoadedMsg{project: p, quota: quota}
+	}
+}
+
+// saveProject creates the project of the form, or updates its settings and
+// quota when it already exists.
+func saveProject(ctx context.Context, form ProjectFormState) tea.Cmd {
+	name := form.project
+	if name == "" {
+		name = strings.TrimSpace(form.name.Value())
+	}
+	metadata := form.metadata()
+	quotaId := form.quotaId
+	create := form.project == ""
+
+	return func() tea.Msg {
+		limit, changed, err := form.submittedLimit()
+		if err != nil {
+			return projectSavedMsg{project: name, err: err}
+		}
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return projectSavedMsg{project: name, err: err}
+		}
+
+		if create {
+			if err := harborClient.CreateProject(ctx, name, metadata, limit); err != nil {
+				return projectSavedMsg{project: name, err: fmt.Errorf("failed to create project %s: %w", name, err)}
+			}
+			return projectSavedMsg{project: name}
+		}
+
+		if err := harborClient.UpdateProject(ctx, name, metadata); err != nil {
+			return projectSavedMsg{project: name, err: fmt.Errorf("failed to update project %s: %w", name, err)}
+		}
+
+		if quotaId != 0 && changed {
+			if err := harborClient.UpdateQuota(ctx, quotaId, limit); err != nil {
+				return projectSavedMsg{project: name, err: fmt.Errorf("failed to update quota of %s: %w", name, err)}
+			}
+		}
+
+		return projectSavedMsg{project: name}
+	}
+}
+
+// parseBytes reads sizes such as "10 GiB", "500MB" or "1024". An empty value
+// or -1 means no limit, other negative values are refused.
+func parseBytes(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "-1" || value == "∞" {
+		return harbor.StorageUnlimited, nil
+	}
+
+	units := map[string]int64{
+		"":    1,
+		"B":   1,
+		"K":   1 << 10,
+		"KB":  1 << 10,
+		"KIB": 1 << 10,
+		"M":   1 << 20,
+		"MB":  1 << 20,
+		"MIB": 1 << 20,
+		"G":   1 << 30,
+		"GB":  1 << 30,
+		"GIB": 1 << 30,
+		"T":   1 << 40,
+		"TB":  1 << 40,
+		"TIB": 1 << 40,
+	}
+
+	// The number is made of digits and a decimal point, a sign is refused
+	number, unit := value, ""
+	if i := strings.IndexFunc(value, func(r rune) bool { return (r < '0' || r > '9') && r != '.' }); i >= 0 {
+		number, unit = value[:i], strings.ToUpper(strings.TrimSpace(value[i:]))
+	}
+
+	multiplier, ok := units[unit]
+	if !ok || number == "" {
+		return 0, fmt.Errorf("invalid size %q, use a value such as 10GiB or -1 for no limit", value)
+	}
+
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", value, err)
+	}
+
+	bytes := size * float64(multiplier)
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %q, it is too large", value)
+	}
+
+	return int64(bytes), nil
+}
+
+// submittedLimit returns the storage limit typed in the form and whether it
+// differs from the current one. formatLimit rounds, an untouched quota keeps
+// its exact value.
+func (s ProjectFormState) submittedLimit() (int64, bool, error) {
+	value := s.quota.Value()
+	if s.project != "" && value == formatLimit(s.limit) {
+		return s.limit, false, nil
+	}
+
+	limit, err := parseBytes(value)
+	if err != nil {
+		return 0, false, err
+	}
+	return limit, limit != s.limit, nil
+}
+
+// formatLimit renders a storage limit the way parseBytes reads it.
+func formatLimit(limit int64) string {
+	if limit == harbor.StorageUnlimited {
+		return "-1"
+	}
+	return strings.ReplaceAll(formatBytes(limit), " ", "")
+}
+
+func (s ProjectFormState) metadata() harbor.Metadata {
+	return harbor.Metadata{
+		Public:     strconv.FormatBool(s.public),
+		AutoScan:   strconv.FormatBool(s.autoScan),
+		PreventVul: strconv.FormatBool(s.preventVul),
+		Severity:   harbor.ProjectSeverities[s.severity],
+	}
+}
+
+// fields lists the fields that can be focused. The name of an existing
+// project cannot be changed and its quota is hidden when unknown.
+func (s ProjectFormState) fields() []projectField {
+	fields := []projectField{publicField, autoScanField, preventVulField, severityField}
+	if s.project == "" {
+		fields = append([]projectField{nameField}, fields...)
+	}
+	if s.project == "" || s.quotaId != 0 {
+		fields = append(fields, quotaField)
+	}
+	return fields
+}
+
+// setFocus moves the focus to field, focusing its text input if any.
+func (s ProjectFormState) setFocus(field projectField) (ProjectFormState, tea.Cmd) {
+	s.focus = field
+	s.name.Blur()
+	s.quota.Blur()
+
+	switch field {
+	case nameField:
+		return s, s.name.Focus()
+	case quotaField:
+		return s, s.quota.Focus()
+	}
+	return s, nil
+}
+
+// moveFocus focuses the field offset positions away from the current one.
+func (s ProjectFormState) moveFocus(offset int) (ProjectFormState, tea.Cmd) {
+	fields := s.fields()
+	i := slices.Index(fields, s.focus)
+	i = (i + offset + len(fields)) % len(fields)
+	return s.setFocus(fields[i])
+}
+
+// toggle flips the focused checkbox or cycles the severity by offset.
+func (s ProjectFormState) toggle(offset int) ProjectFormState {
+	switch s.focus {
+	case publicField:
+		s.public = !s.public
+	case autoScanField:
+		s.autoScan = !s.autoScan
+	case preventVulField:
+		s.preventVul = !s.preventVul
+	case severityField:
+		n := len(harbor.ProjectSeverities)
+		s.severity = (s.severity + offset + n) % n
+	}
+	return s
+}
+
+func checkbox(checked bool) string {
+	if checked {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+// formLine renders a labelled form field, highlighted when focused.
+func formLine(focused bool, label string, value string) string {
+	line := fmt.Sprintf("%-32s %s", label, value)
+	if focused {
+		return focusedFieldStyle.Render(line)
+	}
+	return line
+}
+
+func (s ProjectFormState) fieldView(field projectField, label string, value string) string {
+	return formLine(field == s.focus, label, value)
+}
+
+func (m model) projectFormView() string {
+	s := m.state.projectForm
+	if s.loading {
+		return "Loading..."
+	}
+
+	title := "New project"
+	if s.project != "" {
+		title = fmt.Sprintf("Project %s", s.project)
+	}
+
+	lines := []string{}
+	if s.project == "" {
+		lines = append(lines, s.fieldView(nameField, "Name", s.name.View()))
+	}
+	lines = append(lines,
+		s.fieldView(publicField, "Public", checkbox(s.public)),
+		s.fieldView(autoScanField, "Scan images on push", checkbox(s.autoScan)),
+		s.fieldView(preventVulField, "Prevent vulnerable images", checkbox(s.preventVul)),
+		s.fieldView(severityField, "Severity threshold", fmt.Sprintf("< %s >", harbor.ProjectSeverities[s.severity])),
+	)
+	if slices.Contains(s.fields(), quotaField) {
+		lines = append(lines, s.fieldView(quotaField, "Storage quota (-1 for no limit)", s.quota.View()))
+	} else {
+		lines = append(lines, infoStyle.Render("Storage quota can only be changed by administrators"))
+	}
+
+	help := "tab/↑/↓ move, space/←/→ change, enter save, esc cancel"
+	if s.saving {
+		help = "Saving..."
+	}
+	lines = append(lines, "", infoStyle.Render(help))
+
+	return section(title, lines)
+}
+
+func (m model) projectFormUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	s := m.state.projectForm
+
+	switch msg := msg.(type) {
+	case projectFormLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m = m.setError(msg.err)
+			m = m.SwitchPage(projectsPage)
+			return m, nil
+		}
+
+		s.loading = false
+		s.name.SetValue(msg.project.Name)
+		s.public = msg.project.Metadata.Public == "true"
+		s.autoScan = msg.project.Metadata.AutoScan == "true"
+		s.preventVul = msg.project.Metadata.PreventVul == "true"
+		s.severity = max(slices.Index(harbor.ProjectSeverities, msg.project.Metadata.Severity), 0)
+		if msg.quota != nil {
+			s.quotaId = msg.quota.Id
+			s.limit = msg.quota.Hard.Storage
+			s.quota.SetValue(formatLimit(s.limit))
+		}
+		m.state.projectForm = s
+		return m, nil
+	case projectSavedMsg:
+		s.saving = false
+		m.state.projectForm = s
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		s.cancel()
+		m = m.setInfo(fmt.Sprintf("Project %s saved", msg.project))
+		return m.reloadProjects()
+	case tea.KeyMsg:
+		if s.loading || s.saving {
+			if msg.String() == "esc" {
+				s.cancel()
+				m = m.SwitchPage(projectsPage)
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "esc":
+			s.cancel()
+			m = m.SwitchPage(projectsPage)
+			return m, nil
+		case "tab", "down":
+			m.state.projectForm, cmd = s.moveFocus(1)
+			return m, cmd
+		case "shift+tab", "up":
+			m.state.projectForm, cmd = s.moveFocus(-1)
+			return m, cmd
+		case "enter":
+			if s.project == "" && strings.TrimSpace(s.name.Value()) == "" {
+				m = m.setError(errors.New("the project needs a name"))
+				return m, nil
+			}
+			s.saving = true
+			m.state.projectForm = s
+			return m, saveProject(s.ctx, s)
+		case " ", "left", "right":
+			if s.focus != nameField && s.focus != quotaField {
+				offset := 1
+				if msg.String() == "left" {
+					offset = -1
+				}
+				m.state.projectForm = s.toggle(offset)
+				return m, nil
+			}
+		}
+	}
+
+	switch s.focus {
+	case nameField:
+		s.name, cmd = s.name.Update(msg)
+	case quotaField:
+		s.quota, cmd = s.quota.Update(msg)
+	}
+	m.state.projectForm = s
+
+	return m, cmd
+}
+
+// NewProjectFormState returns the form editing project, loading its current
+// settings, or creating a new project when project is empty.
+func (m model) NewProjectFormState(project string) (ProjectFormState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+
+	name := textinput.New()
+	name.Prompt = ""
+	name.Placeholder = "project name"
+	quota := textinput.New()
+	quota.Prompt = ""
+	quota.Placeholder = "10GiB"
+	quota.SetValue("-1")
+
+	state := ProjectFormState{
+		project: project,
+		loading: project != "",
+		name:    name,
+		quota:   quota,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	if project == "" {
+		return state.setFocus(nameField)
+	}
+
+	state.focus = publicField
+	return state, fetchProjectForm(ctx, project)
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "", want: harbor.StorageUnlimited},
+		{value: "-1", want: harbor.StorageUnlimited},
+		{value: " -1 ", want: harbor.StorageUnlimited},
+		{value: "∞", want: harbor.StorageUnlimited},
+		{value: "1024", want: 1024},
+		{value: "0", want: 0},
+		{value: "10 GiB", want: 10 << 30},
+		{value: "500MB", want: 500 << 20},
+		{value: "1.5gib", want: 3 << 29},
+		{value: "2T", want: 2 << 40},
+		{value: "-2", wantErr: true},
+		{value: "-10GiB", wantErr: true},
+		{value: "+10GiB", wantErr: true},
+		{value: "GiB", wantErr: true},
+		{value: "10 PiB", wantErr: true},
+		{value: "1.2.3", wantErr: true},
+		{value: "ten", wantErr: true},
+		{value: "99999999999TiB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseBytes(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBytes(%q) error = %v, wantErr %t", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseBytes(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLimit(t *testing.T) {
+	tests := []struct {
+		limit int64
+		want  string
+		// exact is set when parseBytes reads the exact limit back
+		exact bool
+	}{
+		{limit: harbor.StorageUnlimited, want: "-1", exact: true},
+		{limit: 0, want: "0.00B", exact: true},
+		{limit: 512, want: "512.00B", exact: true},
+		{limit: 10 << 30, want: "10.00GiB", exact: true},
+		{limit: 3 << 29, want: "1.50GiB", exact: true},
+		{limit: 10000000000, want: "9.31GiB", exact: false},
+	}
+
+	for _, tt := range tests {
+		got := formatLimit(tt.limit)
+		if got != tt.want {
+			t.Errorf("formatLimit(%d) = %q, want %q", tt.limit, got, tt.want)
+		}
+
+		parsed, err := parseBytes(got)
+		if err != nil || (parsed == tt.limit) != tt.exact {
+			t.Errorf("parseBytes(formatLimit(%d)) = %d, %v, exact round trip %t", tt.limit, parsed, err, tt.exact)
+		}
+	}
+}
+
+func TestSubmittedLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		project     string
+		limit       int64
+		value       string
+		want        int64
+		wantChanged bool
+		wantErr     bool
+	}{
+		{name: "untouched round quota", project: "library", limit: 10 << 30, value: "10.00GiB", want: 10 << 30},
+		{name: "untouched quota that does not round trip", project: "library", limit: 10000000000, value: "9.31GiB", want: 10000000000},
+		{name: "untouched unlimited quota", project: "library", limit: harbor.StorageUnlimited, value: "-1", want: harbor.StorageUnlimited},
+		{name: "edited quota", project: "library", limit: 10000000000, value: "20GiB", want: 20 << 30, wantChanged: true},
+		{name: "same quota typed differently", project: "library", limit: 10 << 30, value: "10 GiB", want: 10 << 30},
+		{name: "quota removed", project: "library", limit: 10 << 30, value: "-1", want: harbor.StorageUnlimited, wantChanged: true},
+		{name: "invalid quota", project: "library", limit: 10 << 30, value: "-5GiB", wantErr: true},
+		{name: "new project", project: "", limit: 0, value: "-1", want: harbor.StorageUnlimited, wantChanged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := ProjectFormState{project: tt.project, limit: tt.limit, quota: textinput.New()}
+			form.quota.SetValue(tt.value)
+
+			got, changed, err := form.submittedLimit()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("submittedLimit() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got != tt.want || changed != tt.wantChanged {
+				t.Errorf("submittedLimit() = %d, %t, want %d, %t", got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
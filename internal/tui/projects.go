@@ -1,48 +1,358 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"strconv"
 
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/mathiasdonoso/harborw/internal/api/harbor"
 )
 
+// Values of Project.Artifacts while the artifacts are not known.
+const (
+	artifactsUncounted = -1
+	artifactsCounting  = -2
+)
+
 type Project struct {
+	Id        int
 	Name      string
 	RepoCount int
+	// RoleId is the role of the current user in the project
+	RoleId int
+	// Artifacts is artifactsCounting until counted, artifactsUncounted when
+	// counting failed
+	Artifacts    int
+	QuotaKnown   bool
+	StorageUsed  int64
+	StorageLimit int64
 }
 
 func (p Project) ToRow() []string {
-	columns := []string{p.Name, strconv.Itoa(p.RepoCount)}
+	artifacts := strconv.Itoa(p.Artifacts)
+	switch p.Artifacts {
+	case artifactsUncounted:
+		artifacts = "-"
+	case artifactsCounting:
+		artifacts = "..."
+	}
+
+	used, limit, bar := "", "", ""
+	if p.QuotaKnown {
+		used = formatBytes(p.StorageUsed)
+		limit = "∞"
+		if p.StorageLimit != harbor.StorageUnlimited {
+			limit = formatBytes(p.StorageLimit)
+		}
+		filled, empty, percent := usageBar(p.usage())
+		bar = fmt.Sprintf("%s%s %s", filled, empty, percent)
+	}
+
+	columns := []string{p.Name, strconv.Itoa(p.RepoCount), artifacts, used, limit, bar}
 	return columns
 }
 
+func projectRows(projects []Project) []table.Row {
+	rows := make([]table.Row, len(projects))
+	for i, p := range projects {
+		rows[i] = p.ToRow()
+	}
+	return rows
+}
+
 type ProjectsState struct {
-	table table.Model
-	data  []Project
+	table  table.Model
+	data   []Project
+	filter string
+	prompt PromptState
+	// sortByName orders projects by name instead of storage consumption
+	sortByName   bool
+	pendingUsage int
+	// load identifies the current load, results of previous ones are dropped
+	load   int
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type projectDeletedMsg struct {
+	project string
+	err     error
+}
+
+type projectsLoadedMsg struct {
+	load     int
+	projects []Project
+	err      error
+}
+
+func fetchProjects(ctx context.Context, load int, opts harbor.QueryOptions) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			slog.Error("Error creating harbor client", "err", err)
+			return projectsLoadedMsg{load: load, err: err}
+		}
+
+		r, err := harborClient.FetchProjects(ctx, opts)
+		if err != nil {
+			slog.Error("Error fetching projects", "err", err)
+			return projectsLoadedMsg{load: load, err: fmt.Errorf("failed to fetch projects: %w", err)}
+		}
+
+		// Listing quotas requires admin rights, members fall back to the
+		// summary of each project
+		quotas := map[int]harbor.QuotaResult{}
+		q, err := harborClient.FetchQuotas(ctx)
+		if err != nil {
+			slog.Debug("Could not fetch quotas", "err", err)
+		} else {
+			for _, quota := range *q {
+				quotas[quota.Ref.Id] = quota
+			}
+		}
+
+		projects := make([]Project, len(*r))
+		for i, p := range *r {
+			project := Project{
+				Id:        p.ProjectId,
+				Name:      p.Name,
+				RepoCount: p.RepoCount,
+				RoleId:    p.CurrentUserRoleId,
+				Artifacts: artifactsCounting,
+			}
+			if quota, ok := quotas[p.ProjectId]; ok {
+				project.QuotaKnown = true
+				project.StorageUsed = quota.Used.Storage
+				project.StorageLimit = quota.Hard.Storage
+			}
+			projects[i] = project
+		}
+
+		return projectsLoadedMsg{load: load, projects: projects}
+	}
+}
+
+// fetch starts a new load of the projects matching the filter, results of
+// previous loads are dropped from now on.
+func (s ProjectsState) fetch() (ProjectsState, tea.Cmd) {
+	s.load++
+	return s, fetchProjects(s.ctx, s.load, harbor.QueryOptions{Name: s.filter})
+}
+
+func deleteProject(ctx context.Context, project string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return projectDeletedMsg{project: project, err: err}
+		}
+
+		if err := harborClient.DeleteProject(ctx, project); err != nil {
+			slog.Error("Error deleting project", "project", project, "err", err)
+			return projectDeletedMsg{project: project, err: fmt.Errorf("failed to delete project %s: %w", project, err)}
+		}
+
+		return projectDeletedMsg{project: project}
+	}
+}
+
+// reloadProjects shows the projects page and fetches its data again,
+// keeping the current filter.
+func (m model) reloadProjects() (model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.state.projects, cmd = newEmptyProjectsState(m.state.projects, "Loading...").fetch()
+	m = m.SwitchPage(projectsPage)
+	return m, cmd
 }
 
 func (m model) projectsView() string {
-	return m.state.projects.table.View()
+	state := m.state.projects
+	content := state.table.View()
+
+	if len(state.data) > 0 {
+		active := state.data[state.table.Cursor()]
+		content = lipgloss.JoinVertical(lipgloss.Left, content, usageView(active))
+	}
+
+	return withPrompt(content, state.prompt)
 }
 
 func (m model) projectsUpdate(msg tea.Msg) (model, tea.Cmd) {
 	var cmd tea.Cmd
+	if m.state.projects.prompt.handles(msg) {
+		action := m.state.projects.prompt.action
+		prompt, value, submitted, cmd := m.state.projects.prompt.update(msg)
+		m.state.projects.prompt = prompt
+		if !submitted {
+			return m, cmd
+		}
+
+		switch action {
+		case filterPromptAction:
+			m.state.projects.filter = value
+			m.state.projects, cmd = newEmptyProjectsState(m.state.projects, "Loading...").fetch()
+			return m, cmd
+		case deleteProjectPromptAction:
+			if len(m.state.projects.data) == 0 {
+				return m, nil
+			}
+			active := m.state.projects.data[m.state.projects.table.Cursor()]
+			if value != active.Name {
+				m = m.setInfo("Project not deleted, the name did not match")
+				return m, nil
+			}
+			return m, deleteProject(m.state.projects.ctx, active.Name)
+		}
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
+	case projectsLoadedMsg:
+		if msg.load != m.state.projects.load {
+			// Result of a load replaced since
+			return m, nil
+		}
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.projects = newEmptyProjectsState(m.state.projects, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		if m.state.projects.sortByName {
+			sortProjectsByName(msg.projects)
+		} else {
+			sortProjectsByUsage(msg.projects)
+		}
+		m.state.projects = newProjectsState(m.state.projects, msg.projects)
+
+		state := m.state.projects
+		cmds := []tea.Cmd{}
+		for _, p := range msg.projects {
+			if !p.QuotaKnown {
+				cmds = append(cmds, fetchProjectUsage(state.ctx, state.load, p.Name))
+			}
+		}
+		m.state.projects.pendingUsage = len(cmds)
+		for _, p := range msg.projects {
+			cmds = append(cmds, countProjectArtifacts(state.ctx, state.load, p.Name))
+		}
+
+		return m, tea.Batch(cmds...)
+	case projectUsageMsg:
+		return m.projectUsageUpdate(msg)
+	case projectArtifactsMsg:
+		return m.projectArtifactsUpdate(msg)
+	case projectDeletedMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+		m = m.setInfo(fmt.Sprintf("Project %s deleted", msg.project))
+		return m.reloadProjects()
 	case tea.KeyMsg:
 		switch msg.String() {
+		case "esc":
+			m.state.projects.cancel()
+			m.state.projects.ctx, m.state.projects.cancel = newPageContext()
+			if len(m.state.projects.data) == 0 {
+				m.state.projects = newEmptyProjectsState(m.state.projects, "Cancelled, press u to reload")
+			}
+			m = m.setInfo("Cancelled pending requests")
+			return m, nil
+		case "u":
+			// Refresh
+			return m.reloadProjects()
+		case "o":
+			// Toggle ordering by name or by storage consumption
+			m.state.projects.sortByName = !m.state.projects.sortByName
+			m.state.projects = m.state.projects.sorted()
+			return m, nil
+		case "n":
+			// Create a project
+			m.state.projectForm, cmd = m.NewProjectFormState("")
+			m = m.SwitchPage(projectFormPage)
+			return m, cmd
+		case "e":
+			// Edit the settings and quota of the project
+			if len(m.state.projects.data) == 0 {
+				return m, nil
+			}
+			active := m.state.projects.data[m.state.projects.table.Cursor()]
+			m.state.projectForm, cmd = m.NewProjectFormState(active.Name)
+			m = m.SwitchPage(projectFormPage)
+			return m, cmd
+		case "D":
+			// Delete an empty project, Harbor refuses when it still holds repositories
+			if len(m.state.projects.data) == 0 {
+				return m, nil
+			}
+			active := m.state.projects.data[m.state.projects.table.Cursor()]
+			if active.RepoCount > 0 {
+				m = m.setInfo(fmt.Sprintf("Project %s still holds %d repositories, delete them first", active.Name, active.RepoCount))
+				return m, nil
+			}
+			label := fmt.Sprintf("Delete project %s? Type its name to confirm", active.Name)
+			m.state.projects.prompt, cmd = newPrompt(label, "", deleteProjectPromptAction)
+			return m, cmd
+		case "W":
+			// Manage the webhooks of the project
+			if len(m.state.projects.data) == 0 {
+				return m, nil
+			}
+			active := m.state.projects.data[m.state.projects.table.Cursor()]
+			m.state.webhooks, cmd = m.NewWebhooksState(active)
+			m = m.SwitchPage(webhooksPage)
+			return m, cmd
+		case "M":
+			// Manage the members of the project
+			if len(m.state.projects.data) == 0 {
+				return m, nil
+			}
+			active := m.state.projects.data[m.state.projects.table.Cursor()]
+			m.state.members, cmd = m.NewMembersState(active)
+			m = m.SwitchPage(membersPage)
+			return m, cmd
+		case "R":
+			// Manage the robot accounts of the project
+			if len(m.state.projects.data) == 0 {
+				return m, nil
+			}
+			active := m.state.projects.data[m.state.projects.table.Cursor()]
+			m.state.robots, cmd = m.NewRobotsState(&active, projectsPage)
+			m = m.SwitchPage(robotsPage)
+			return m, cmd
+		case "A":
+			// Show the audit logs of the project
+			if len(m.state.projects.data) == 0 {
+				return m, nil
+			}
+			active := m.state.projects.data[m.state.projects.table.Cursor()]
+			m.state.audit, cmd = m.NewAuditState(active.Name, "", projectsPage)
+			m = m.SwitchPage(auditPage)
+			return m, cmd
+		case "/":
+			m.state.projects.prompt, cmd = newPrompt("Filter projects", m.state.projects.filter, filterPromptAction)
+			return m, cmd
 		case "enter":
+			if len(m.state.projects.data) == 0 {
+				return m, nil
+			}
 			rowIndex := m.state.projects.table.Cursor()
 			active := m.state.projects.data[rowIndex]
-			m.state.repositories = m.NewRepositoriesState(active.Name)
 			slog.Debug(fmt.Sprintf("Selecting project: %s", active.Name))
+			m.state.repositories, cmd = m.NewRepositoriesState(active.Name)
 			m = m.SwitchPage(repositoriesPage)
-			return m, nil
+			return m, cmd
 		}
 	}
 
@@ -53,67 +363,53 @@ func (m model) projectsUpdate(msg tea.Msg) (model, tea.Cmd) {
 var PROJECTS_COLUMNS = []table.Column{
 	{Title: "Project", Width: 40},
 	{Title: "Repositories count", Width: 18},
+	{Title: "Artifacts", Width: 10},
+	{Title: "Storage used", Width: 12},
+	{Title: "Quota", Width: 12},
+	{Title: "Usage", Width: 16},
 }
 
-func newEmptyProjectsState() ProjectsState {
+// newEmptyProjectsState keeps the request context of state and shows
+// message instead of data.
+func newEmptyProjectsState(state ProjectsState, message string) ProjectsState {
 	t := table.New(
 		table.WithColumns(PROJECTS_COLUMNS),
-		table.WithRows([]table.Row{{"No data available", ""}}),
+		table.WithRows([]table.Row{{message, "", "", "", "", ""}}),
 		table.WithFocused(true),
 		table.WithHeight(2),
 	)
 
 	t.SetStyles(GetTableDefaultStyles())
 
-	state := ProjectsState{
-		table: t,
-		data:  []Project{},
-	}
+	state.table = t
+	state.data = []Project{}
 
 	return state
 }
 
-func (m model) NewProjectsState() ProjectsState {
-	harborClient, err := harbor.NewHarborApiClient(http.DefaultClient)
-	if err != nil {
-		slog.Error("Error creating harbor client", "err", err)
-		return newEmptyProjectsState()
-	}
-	r, err := harborClient.FetchProjects()
-	if err != nil {
-		slog.Error("Error fetching projects", "err", err)
-		return newEmptyProjectsState()
-	}
-
-	projects := make([]Project, len(*r))
-	for i, p := range *r {
-		project := Project{
-			Name:      p.Name,
-			RepoCount: p.RepoCount,
-		}
-		projects[i] = project
-	}
-
-	rows := make([]table.Row, len(*r))
-	for i, a := range projects {
-		rows[i] = a.ToRow()
-	}
-
+func newProjectsState(state ProjectsState, projects []Project) ProjectsState {
 	t := table.New(
 		table.WithColumns(PROJECTS_COLUMNS),
-		table.WithRows(rows),
+		table.WithRows(projectRows(projects)),
 		table.WithFocused(true),
 		table.WithHeight(21),
 	)
 
 	t.SetStyles(GetTableDefaultStyles())
 
-	state := ProjectsState{
-		table: t,
-		data:  projects,
-	}
+	state.table = t
+	state.data = projects
 
 	slog.Debug("New Project state created.")
 
 	return state
 }
+
+// NewProjectsState returns a loading projects state along with the command
+// that fetches its data.
+func (m model) NewProjectsState() (ProjectsState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := newEmptyProjectsState(ProjectsState{ctx: ctx, cancel: cancel}, "Loading...")
+
+	return state.fetch()
+}
@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDeleteProjectNeedsItEmpty(t *testing.T) {
+	tests := []struct {
+		repoCount  int
+		wantPrompt bool
+	}{
+		{repoCount: 0, wantPrompt: true},
+		{repoCount: 2, wantPrompt: false},
+	}
+
+	for _, tt := range tests {
+		m := model{}
+		m.state.projects = newProjectsState(ProjectsState{}, []Project{{Name: "team", RepoCount: tt.repoCount}})
+
+		m, _ = m.projectsUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+		if got := m.state.projects.prompt.active(); got != tt.wantPrompt {
+			t.Errorf("with %d repositories, prompt shown = %t, want %t", tt.repoCount, got, tt.wantPrompt)
+		}
+		if !tt.wantPrompt && m.state.footer.message == "" {
+			t.Errorf("with %d repositories, no reason given for not deleting", tt.repoCount)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// promptAction identifies what a page does with the value of its prompt
+// once it is submitted.
+type promptAction int
+
+const (
+	noPromptAction promptAction = iota
+	filterPromptAction
+	addTagPromptAction
+	deleteTagPromptAction
+	moveTagPromptAction
+	addLabelPromptAction
+	removeLabelPromptAction
+	deleteArtifactsPromptAction
+	deleteAccessoryPromptAction
+	packageSearchPromptAction
+	searchPromptAction
+	deleteProjectPromptAction
+	deleteRepositoryPromptAction
+	copyArtifactsPromptAction
+	createRobotPromptAction
+	robotExpiryWindowPromptAction
+	refreshRobotSecretPromptAction
+	deleteRobotPromptAction
+	disableRobotPromptAction
+	addMemberPromptAction
+	memberRolePromptAction
+	deleteMemberPromptAction
+	deleteWebhookPromptAction
+	toggleWebhookPromptAction
+	startReplicationPromptAction
+)
+
+type PromptState struct {
+	input  textinput.Model
+	action promptAction
+}
+
+// newPrompt returns a focused prompt asking for label, prefilled with value.
+func newPrompt(label string, value string, action promptAction) (PromptState, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = label + ": "
+	input.SetValue(value)
+	cmd := input.Focus()
+
+	return PromptState{
+		input:  input,
+		action: action,
+	}, cmd
+}
+
+// withSuggestions lets the user autocomplete the prompt with tab.
+func (p PromptState) withSuggestions(suggestions []string) PromptState {
+	p.input.ShowSuggestions = true
+	p.input.SetSuggestions(suggestions)
+	return p
+}
+
+func (p PromptState) active() bool {
+	return p.action != noPromptAction
+}
+
+// handles reports whether msg belongs to the open prompt: the keys typed by
+// the user and the blinking of its cursor. Every other message, such as the
+// results of fetches, must still reach the page while the prompt is open.
+func (p PromptState) handles(msg tea.Msg) bool {
+	if !p.active() {
+		return false
+	}
+
+	switch msg.(type) {
+	case tea.KeyMsg, cursor.BlinkMsg:
+		return true
+	}
+	return false
+}
+
+// update forwards msg to the prompt. submitted is true once the user presses
+// enter, then the prompt is closed and value holds what was typed. Pressing
+// esc closes the prompt without submitting it.
+func (p PromptState) update(msg tea.Msg) (prompt PromptState, value string, submitted bool, cmd tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			return PromptState{}, p.input.Value(), true, nil
+		case "esc":
+			return PromptState{}, "", false, nil
+		}
+	}
+
+	p.input, cmd = p.input.Update(msg)
+	return p, "", false, cmd
+}
+
+// withPrompt renders the prompt, when active, below the page content.
+func withPrompt(content string, prompt PromptState) string {
+	if !prompt.active() {
+		return content
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, content, prompt.View())
+}
+
+func (p PromptState) View() string {
+	if !p.active() {
+		return ""
+	}
+	return p.input.View()
+}
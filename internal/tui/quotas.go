@@ -0,0 +1,211 @@
+package tui
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+const usageBarWidth = 10
+
+type projectUsageMsg struct {
+	load    int
+	project string
+	used    int64
+	limit   int64
+	err     error
+}
+
+type projectArtifactsMsg struct {
+	load      int
+	project   string
+	artifacts int
+	err       error
+}
+
+// fetchProjectUsage reads the storage quota of project from its summary, for
+// users who cannot list every quota. load is the projects load it belongs to.
+func fetchProjectUsage(ctx context.Context, load int, project string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return projectUsageMsg{load: load, project: project, err: err}
+		}
+
+		summary, err := harborClient.FetchProjectSummary(ctx, project)
+		if err != nil {
+			return projectUsageMsg{load: load, project: project, err: err}
+		}
+
+		return projectUsageMsg{load: load, project: project, used: summary.Quota.Used.Storage, limit: summary.Quota.Hard.Storage}
+	}
+}
+
+// countProjectArtifacts sums the artifacts of every repository of project,
+// listing its repositories once. load is the projects load it belongs to.
+func countProjectArtifacts(ctx context.Context, load int, project string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return projectArtifactsMsg{load: load, project: project, err: err}
+		}
+
+		repositories, err := harborClient.FetchRepositories(ctx, project, harbor.QueryOptions{})
+		if err != nil {
+			return projectArtifactsMsg{load: load, project: project, err: err}
+		}
+
+		msg := projectArtifactsMsg{load: load, project: project}
+		for _, r := range *repositories {
+			msg.artifacts += r.ArtifactCount
+		}
+		return msg
+	}
+}
+
+// usage returns the fraction of the quota used by the project, or -1 when it
+// has no limit.
+func (p Project) usage() float64 {
+	if p.StorageLimit <= 0 {
+		return -1
+	}
+	return float64(p.StorageUsed) / float64(p.StorageLimit)
+}
+
+// usageBar draws the quota usage as a bar made of filled and empty parts.
+func usageBar(usage float64) (string, string, string) {
+	if usage < 0 {
+		return "", "", "no limit"
+	}
+
+	filled := min(int(usage*usageBarWidth+0.5), usageBarWidth)
+	return strings.Repeat("█", filled), strings.Repeat("░", usageBarWidth-filled), fmt.Sprintf("%3.0f%%", usage*100)
+}
+
+func usageStyle(usage float64) lipgloss.Style {
+	switch {
+	case usage >= 0.9:
+		return unhealthyStyle
+	case usage >= 0.7:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	}
+	return healthyStyle
+}
+
+// usageView details the storage of project with a bar colored by how close
+// it is to its quota.
+func usageView(p Project) string {
+	if !p.QuotaKnown {
+		return infoStyle.Render(fmt.Sprintf("%s: storage usage unknown", p.Name))
+	}
+
+	limit := "no limit"
+	if p.StorageLimit != harbor.StorageUnlimited {
+		limit = formatBytes(p.StorageLimit)
+	}
+
+	filled, empty, percent := usageBar(p.usage())
+	bar := usageStyle(p.usage()).Render(filled) + infoStyle.Render(empty)
+
+	return fmt.Sprintf("%s: %s %s %s of %s", p.Name, bar, percent, formatBytes(p.StorageUsed), limit)
+}
+
+// sortProjectsByUsage puts the projects using the most storage first.
+func sortProjectsByUsage(projects []Project) {
+	slices.SortStableFunc(projects, func(a, b Project) int {
+		return cmp.Compare(b.StorageUsed, a.StorageUsed)
+	})
+}
+
+func sortProjectsByName(projects []Project) {
+	slices.SortStableFunc(projects, func(a, b Project) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+func (m model) projectUsageUpdate(msg projectUsageMsg) (model, tea.Cmd) {
+	if errors.Is(msg.err, context.Canceled) || msg.load != m.state.projects.load {
+		return m, nil
+	}
+
+	state := m.state.projects
+	state.pendingUsage--
+
+	if msg.err != nil {
+		slog.Error("Error fetching project usage", "project", msg.project, "err", msg.err)
+	}
+
+	for i, p := range state.data {
+		if p.Name == msg.project && msg.err == nil {
+			state.data[i].QuotaKnown = true
+			state.data[i].StorageUsed = msg.used
+			state.data[i].StorageLimit = msg.limit
+		}
+	}
+
+	state.table.SetRows(projectRows(state.data))
+	if state.pendingUsage == 0 && !state.sortByName {
+		// Every usage is known, the order by consumption is final
+		state = state.sorted()
+	}
+	m.state.projects = state
+
+	return m, nil
+}
+
+func (m model) projectArtifactsUpdate(msg projectArtifactsMsg) (model, tea.Cmd) {
+	if errors.Is(msg.err, context.Canceled) || msg.load != m.state.projects.load {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m = m.setError(fmt.Errorf("failed to count the artifacts of %s: %w", msg.project, msg.err))
+	}
+
+	state := m.state.projects
+	for i, p := range state.data {
+		if p.Name != msg.project {
+			continue
+		}
+		state.data[i].Artifacts = artifactsUncounted
+		if msg.err == nil {
+			state.data[i].Artifacts = msg.artifacts
+		}
+	}
+
+	state.table.SetRows(projectRows(state.data))
+	m.state.projects = state
+
+	return m, nil
+}
+
+// sorted orders the projects as chosen by the user, keeping the cursor on the
+// project it was on.
+func (s ProjectsState) sorted() ProjectsState {
+	selected := ""
+	if len(s.data) > 0 {
+		selected = s.data[s.table.Cursor()].Name
+	}
+
+	if s.sortByName {
+		sortProjectsByName(s.data)
+	} else {
+		sortProjectsByUsage(s.data)
+	}
+
+	s.table.SetRows(projectRows(s.data))
+	for i, p := range s.data {
+		if p.Name == selected {
+			s.table.SetCursor(i)
+		}
+	}
+	return s
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUsageBar(t *testing.T) {
+	tests := []struct {
+		usage   float64
+		filled  int
+		empty   int
+		percent string
+	}{
+		{usage: -1, filled: 0, empty: 0, percent: "no limit"},
+		{usage: 0, filled: 0, empty: 10, percent: "  0%"},
+		{usage: 0.04, filled: 0, empty: 10, percent: "  4%"},
+		{usage: 0.05, filled: 1, empty: 9, percent: "  5%"},
+		{usage: 0.5, filled: 5, empty: 5, percent: " 50%"},
+		{usage: 1, filled: 10, empty: 0, percent: "100%"},
+		{usage: 1.5, filled: 10, empty: 0, percent: "150%"},
+	}
+
+	for _, tt := range tests {
+		filled, empty, percent := usageBar(tt.usage)
+		if got := len([]rune(filled)); got != tt.filled {
+			t.Errorf("usageBar(%v) filled %d, want %d", tt.usage, got, tt.filled)
+		}
+		if got := len([]rune(empty)); got != tt.empty {
+			t.Errorf("usageBar(%v) empty %d, want %d", tt.usage, got, tt.empty)
+		}
+		if percent != tt.percent {
+			t.Errorf("usageBar(%v) percent %q, want %q", tt.usage, percent, tt.percent)
+		}
+	}
+}
+
+func projectNames(projects []Project) []string {
+	names := make([]string, len(projects))
+	for i, p := range projects {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func TestProjectsSorted(t *testing.T) {
+	projects := []Project{
+		{Name: "b", StorageUsed: 10},
+		{Name: "a", StorageUsed: 30},
+		{Name: "c", StorageUsed: 30},
+		{Name: "d", StorageUsed: 20},
+	}
+	state := newProjectsState(ProjectsState{}, projects)
+	state.table.SetCursor(3)
+
+	state = state.sorted()
+	if got, want := projectNames(state.data), []string{"a", "c", "d", "b"}; !slices.Equal(got, want) {
+		t.Errorf("sorted by usage %v, want %v", got, want)
+	}
+	if got := state.data[state.table.Cursor()].Name; got != "d" {
+		t.Errorf("cursor on %q, want it kept on d", got)
+	}
+
+	state.sortByName = true
+	state = state.sorted()
+	if got, want := projectNames(state.data), []string{"a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("sorted by name %v, want %v", got, want)
+	}
+	if got := state.data[state.table.Cursor()].Name; got != "d" {
+		t.Errorf("cursor on %q, want it kept on d", got)
+	}
+}
+
+func TestProjectUsageUpdateDropsStaleLoads(t *testing.T) {
+	projects := []Project{
+		{Name: "small", StorageUsed: 10, QuotaKnown: true},
+		{Name: "big"},
+		{Name: "other"},
+	}
+	m := model{}
+	m.state.projects = newProjectsState(ProjectsState{load: 2, pendingUsage: 2}, projects)
+
+	m, _ = m.projectUsageUpdate(projectUsageMsg{load: 1, project: "big", used: 50})
+	if m.state.projects.pendingUsage != 2 {
+		t.Fatalf("pendingUsage = %d after a stale usage, want 2", m.state.projects.pendingUsage)
+	}
+	if m.state.projects.data[1].QuotaKnown {
+		t.Fatal("stale usage was applied")
+	}
+
+	m, _ = m.projectUsageUpdate(projectUsageMsg{load: 2, project: "big", used: 50})
+	if got, want := projectNames(m.state.projects.data), []string{"small", "big", "other"}; !slices.Equal(got, want) {
+		t.Errorf("sorted before every usage was known: %v", got)
+	}
+
+	m, _ = m.projectUsageUpdate(projectUsageMsg{load: 2, project: "other", used: 20})
+	if got, want := projectNames(m.state.projects.data), []string{"big", "other", "small"}; !slices.Equal(got, want) {
+		t.Errorf("sorted %v once every usage was known, want %v", got, want)
+	}
+}
+
+func TestProjectArtifactsUpdateDropsStaleLoads(t *testing.T) {
+	m := model{}
+	m.state.projects = newProjectsState(ProjectsState{load: 2}, []Project{{Name: "a", Artifacts: artifactsCounting}})
+
+	m, _ = m.projectArtifactsUpdate(projectArtifactsMsg{load: 1, project: "a", artifacts: 3})
+	if got := m.state.projects.data[0].Artifacts; got != artifactsCounting {
+		t.Fatalf("Artifacts = %d after a stale count, want artifactsCounting", got)
+	}
+
+	m, _ = m.projectArtifactsUpdate(projectArtifactsMsg{load: 2, project: "a", artifacts: 3})
+	if got := m.state.projects.data[0].Artifacts; got != 3 {
+		t.Errorf("Artifacts = %d, want 3", got)
+	}
+}
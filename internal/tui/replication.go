@@ -0,0 +1,394 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+type ReplicationPolicy struct {
+	Id          int
+	Name        string
+	Source      *harbor.RegistryResult
+	Destination *harbor.RegistryResult
+	Namespace   string
+	Trigger     string
+	Enabled     bool
+	// LastRun is nil when the policy never ran, or until LastRunKnown
+	LastRun      *harbor.ReplicationExecutionResult
+	LastRunKnown bool
+	LastRunErr   error
+}
+
+// endpointName names a replication endpoint, nil being this harbor.
+func endpointName(r *harbor.RegistryResult) string {
+	if r == nil {
+		return "local"
+	}
+	return r.Name
+}
+
+// Remote returns the registry the policy replicates from or to.
+func (p ReplicationPolicy) Remote() *harbor.RegistryResult {
+	if p.Source != nil {
+		return p.Source
+	}
+	return p.Destination
+}
+
+func (p ReplicationPolicy) ToRow() []string {
+	destination := endpointName(p.Destination)
+	if p.Namespace != "" {
+		destination = fmt.Sprintf("%s/%s", destination, p.Namespace)
+	}
+
+	enabled := "no"
+	if p.Enabled {
+		enabled = "yes"
+	}
+
+	lastRun := "never"
+	switch {
+	case p.LastRunErr != nil:
+		lastRun = "unknown"
+	case !p.LastRunKnown:
+		lastRun = "..."
+	case p.LastRun != nil:
+		lastRun = fmt.Sprintf("%s %s", p.LastRun.Status, p.LastRun.StartTime)
+	}
+
+	return []string{
+		p.Name,
+		endpointName(p.Source),
+		destination,
+		p.Trigger,
+		enabled,
+		lastRun,
+	}
+}
+
+type ReplicationState struct {
+	table table.Model
+	data  []ReplicationPolicy
+	// registries holds the up to date status of each registry, by id
+	registries map[int]harbor.RegistryResult
+	prompt     PromptState
+	from       page
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+type replicationLoadedMsg struct {
+	policies   []ReplicationPolicy
+	registries map[int]harbor.RegistryResult
+	err        error
+}
+
+type replicationLastRunMsg struct {
+	policyId  int
+	execution *harbor.ReplicationExecutionResult
+	err       error
+}
+
+type replicationStartedMsg struct {
+	policy      ReplicationPolicy
+	executionId int
+	err         error
+}
+
+func fetchReplication(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return replicationLoadedMsg{err: err}
+		}
+
+		p, err := harborClient.FetchReplicationPolicies(ctx)
+		if err != nil {
+			slog.Error("Error fetching replication policies", "err", err)
+			return replicationLoadedMsg{err: fmt.Errorf("failed to fetch replication policies: %w", err)}
+		}
+
+		r, err := harborClient.FetchRegistries(ctx)
+		if err != nil {
+			slog.Error("Error fetching registries", "err", err)
+			return replicationLoadedMsg{err: fmt.Errorf("failed to fetch registries: %w", err)}
+		}
+
+		registries := map[int]harbor.RegistryResult{}
+		for _, registry := range *r {
+			registries[registry.Id] = registry
+		}
+
+		policies := make([]ReplicationPolicy, len(*p))
+		for i, policy := range *p {
+			trigger := "manual"
+			if policy.Trigger != nil {
+				trigger = policy.Trigger.Type
+				if policy.Trigger.TriggerSettings.Cron != "" {
+					trigger = fmt.Sprintf("%s %s", trigger, policy.Trigger.TriggerSettings.Cron)
+				}
+			}
+
+			policies[i] = ReplicationPolicy{
+				Id:          policy.Id,
+				Name:        policy.Name,
+				Source:      policy.SrcRegistry,
+				Destination: policy.DestRegistry,
+				Namespace:   policy.DestNamespace,
+				Trigger:     trigger,
+				Enabled:     policy.Enabled,
+			}
+		}
+
+		return replicationLoadedMsg{policies: policies, registries: registries}
+	}
+}
+
+// fetchLastRun loads the latest execution of policy. Each policy is loaded
+// on its own so that one failing does not prevent showing the others.
+func fetchLastRun(ctx context.Context, policy ReplicationPolicy) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return replicationLastRunMsg{policyId: policy.Id, err: err}
+		}
+
+		executions, err := harborClient.FetchReplicationExecutions(ctx, policy.Id, 1)
+		if err != nil {
+			slog.Error("Error fetching replication executions", "policy", policy.Name, "err", err)
+			return replicationLastRunMsg{policyId: policy.Id, err: err}
+		}
+
+		msg := replicationLastRunMsg{policyId: policy.Id}
+		if len(*executions) > 0 {
+			msg.execution = &(*executions)[0]
+		}
+		return msg
+	}
+}
+
+// confirmReplication asks before running policy, which copies images
+// between registries.
+func confirmReplication(policy ReplicationPolicy) (PromptState, tea.Cmd) {
+	label := fmt.Sprintf("Run replication %s now? Type yes to confirm", policy.Name)
+	return newPrompt(label, "", startReplicationPromptAction)
+}
+
+// startReplication runs policy manually.
+func startReplication(ctx context.Context, policy ReplicationPolicy) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return replicationStartedMsg{policy: policy, err: err}
+		}
+
+		id, err := harborClient.StartReplication(ctx, policy.Id)
+		if err != nil {
+			slog.Error("Error starting replication", "err", err)
+			return replicationStartedMsg{policy: policy, err: fmt.Errorf("failed to start replication %s: %w", policy.Name, err)}
+		}
+
+		return replicationStartedMsg{policy: policy, executionId: id}
+	}
+}
+
+// registryView details the remote registry of policy.
+func (s ReplicationState) registryView(policy ReplicationPolicy) string {
+	remote := policy.Remote()
+	if remote == nil {
+		return ""
+	}
+
+	// The registry embedded in the policy may not carry its current status
+	registry, ok := s.registries[remote.Id]
+	if !ok {
+		registry = *remote
+	}
+
+	status := registry.Status
+	if status == "" {
+		status = "unknown"
+	}
+
+	return fmt.Sprintf("Registry %s (%s) %s: %s", registry.Name, registry.Type, registry.Url, healthStatus(status))
+}
+
+func (m model) replicationView() string {
+	state := m.state.replication
+	content := state.table.View()
+
+	if len(state.data) > 0 {
+		active := state.data[state.table.Cursor()]
+		content = lipgloss.JoinVertical(lipgloss.Left, content, state.registryView(active))
+	}
+
+	return withPrompt(content, state.prompt)
+}
+
+func (m model) replicationUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.state.replication.prompt.handles(msg) {
+		state := m.state.replication
+		prompt, value, submitted, cmd := state.prompt.update(msg)
+		m.state.replication.prompt = prompt
+		if submitted && state.prompt.action == startReplicationPromptAction && value == "yes" && len(state.data) > 0 {
+			active := state.data[state.table.Cursor()]
+			m = m.setInfo(fmt.Sprintf("Starting replication %s", active.Name))
+			return m, startReplication(state.ctx, active)
+		}
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case replicationLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.replication = newEmptyReplicationState(m.state.replication, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		m.state.replication.registries = msg.registries
+		m.state.replication = newReplicationState(m.state.replication, msg.policies)
+
+		cmds := []tea.Cmd{}
+		for _, p := range msg.policies {
+			cmds = append(cmds, fetchLastRun(m.state.replication.ctx, p))
+		}
+		return m, tea.Batch(cmds...)
+	case replicationLastRunMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+
+		state := m.state.replication
+		for i, p := range state.data {
+			if p.Id == msg.policyId {
+				state.data[i].LastRun = msg.execution
+				state.data[i].LastRunKnown = true
+				state.data[i].LastRunErr = msg.err
+			}
+		}
+		state.table.SetRows(replicationRows(state.data))
+		m.state.replication = state
+		return m, nil
+	case replicationStartedMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		// Follow the new execution
+		m = m.setInfo(fmt.Sprintf("Started replication %s, execution %d", msg.policy.Name, msg.executionId))
+		m.state.replicationExecutions, cmd = m.NewReplicationExecutionsState(msg.policy)
+		m = m.SwitchPage(replicationExecutionsPage)
+		return m, cmd
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			m.state.replication.cancel()
+			return m.returnTo(m.state.replication.from)
+		case "u":
+			// Refresh
+			m.state.replication = newEmptyReplicationState(m.state.replication, "Loading...")
+			return m, fetchReplication(m.state.replication.ctx)
+		case "r":
+			// Run the policy now
+			if len(m.state.replication.data) == 0 {
+				return m, nil
+			}
+			active := m.state.replication.data[m.state.replication.table.Cursor()]
+			m.state.replication.prompt, cmd = confirmReplication(active)
+			return m, cmd
+		case "enter":
+			if len(m.state.replication.data) == 0 {
+				return m, nil
+			}
+			active := m.state.replication.data[m.state.replication.table.Cursor()]
+			m.state.replicationExecutions, cmd = m.NewReplicationExecutionsState(active)
+			m = m.SwitchPage(replicationExecutionsPage)
+			return m, cmd
+		}
+	}
+
+	m.state.replication.table, cmd = m.state.replication.table.Update(msg)
+	return m, cmd
+}
+
+var REPLICATION_COLUMNS = []table.Column{
+	{Title: "Policy", Width: 24},
+	{Title: "Source", Width: 16},
+	{Title: "Destination", Width: 24},
+	{Title: "Trigger", Width: 20},
+	{Title: "Enabled", Width: 8},
+	{Title: "Last run", Width: 36},
+}
+
+// newEmptyReplicationState keeps the request context of state and shows
+// message instead of data.
+func newEmptyReplicationState(state ReplicationState, message string) ReplicationState {
+	t := table.New(
+		table.WithColumns(REPLICATION_COLUMNS),
+		table.WithRows([]table.Row{{message, "", "", "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []ReplicationPolicy{}
+
+	return state
+}
+
+func replicationRows(policies []ReplicationPolicy) []table.Row {
+	rows := make([]table.Row, len(policies))
+	for i, p := range policies {
+		rows[i] = p.ToRow()
+	}
+	return rows
+}
+
+func newReplicationState(state ReplicationState, policies []ReplicationPolicy) ReplicationState {
+	t := table.New(
+		table.WithColumns(REPLICATION_COLUMNS),
+		table.WithRows(replicationRows(policies)),
+		table.WithFocused(true),
+		table.WithHeight(21),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = policies
+
+	slog.Debug("New Replication state created.")
+
+	return state
+}
+
+// NewReplicationState returns a loading replication policies state along
+// with the command that fetches it. from is the page to return to.
+func (m model) NewReplicationState(from page) (ReplicationState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := ReplicationState{
+		from:   from,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	return newEmptyReplicationState(state, "Loading..."), fetchReplication(ctx)
+}
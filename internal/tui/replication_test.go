@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestReplicationPolicyToRow(t *testing.T) {
+	remote := &harbor.RegistryResult{Name: "dockerhub"}
+	run := &harbor.ReplicationExecutionResult{Status: "Succeed", StartTime: "2024-01-01T00:00:00Z"}
+
+	tests := []struct {
+		name   string
+		policy ReplicationPolicy
+		want   []string
+	}{
+		{
+			name:   "pull loading its last run",
+			policy: ReplicationPolicy{Name: "mirror", Source: remote, Namespace: "library", Trigger: "manual", Enabled: true},
+			want:   []string{"mirror", "dockerhub", "local/library", "manual", "yes", "..."},
+		},
+		{
+			name:   "push never run",
+			policy: ReplicationPolicy{Name: "backup", Destination: remote, Trigger: "scheduled", LastRunKnown: true},
+			want:   []string{"backup", "local", "dockerhub", "scheduled", "no", "never"},
+		},
+		{
+			name:   "last run",
+			policy: ReplicationPolicy{Name: "backup", Destination: remote, LastRun: run, LastRunKnown: true},
+			want:   []string{"backup", "local", "dockerhub", "", "no", "Succeed 2024-01-01T00:00:00Z"},
+		},
+		{
+			name:   "last run failed to load",
+			policy: ReplicationPolicy{Name: "backup", Destination: remote, LastRunErr: errors.New("forbidden")},
+			want:   []string{"backup", "local", "dockerhub", "", "no", "unknown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.ToRow(); !slices.Equal(got, tt.want) {
+				t.Errorf("ToRow() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplicationPolicyRemote(t *testing.T) {
+	source, destination := &harbor.RegistryResult{Name: "a"}, &harbor.RegistryResult{Name: "b"}
+
+	if got := (ReplicationPolicy{Source: source}).Remote(); got != source {
+		t.Errorf("Remote() of a pull policy = %v, want its source", got)
+	}
+	if got := (ReplicationPolicy{Destination: destination}).Remote(); got != destination {
+		t.Errorf("Remote() of a push policy = %v, want its destination", got)
+	}
+}
+
+func TestReplicationTasksVisible(t *testing.T) {
+	tasks := []harbor.ReplicationTaskResult{
+		{Id: 1, Status: harbor.ReplicationStatusFailed},
+		{Id: 2, Status: "Succeed"},
+		{Id: 3, Status: harbor.ReplicationStatusFailed},
+	}
+
+	if got := (ReplicationTasksState{data: tasks}).visible(); len(got) != 3 {
+		t.Errorf("visible() shows %d tasks, want all 3", len(got))
+	}
+	got := (ReplicationTasksState{data: tasks, failedOnly: true}).visible()
+	if len(got) != 2 || got[0].Id != 1 || got[1].Id != 3 {
+		t.Errorf("visible() with failed only = %+v, want tasks 1 and 3", got)
+	}
+}
+
+func TestLastLines(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want string
+	}{
+		{text: "a\nb\nc\n", n: 2, want: "b\nc"},
+		{text: "a\nb", n: 5, want: "a\nb"},
+		{text: "", n: 3, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := lastLines(tt.text, tt.n); got != tt.want {
+			t.Errorf("lastLines(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+}
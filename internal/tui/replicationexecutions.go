@@ -0,0 +1,535 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+const (
+	replicationPollInterval = 3 * time.Second
+	// replicationExecutionsLimit is how many of the latest executions are shown
+	replicationExecutionsLimit = 50
+	// taskLogLines is how many lines of a task log are shown
+	taskLogLines = 12
+)
+
+func replicationExecutionRow(e harbor.ReplicationExecutionResult) []string {
+	return []string{
+		strconv.Itoa(e.Id),
+		e.Status,
+		e.Trigger,
+		e.StartTime,
+		e.EndTime,
+		fmt.Sprintf("%d/%d", e.Succeed, e.Total),
+		strconv.Itoa(e.Failed),
+		strconv.Itoa(e.InProgress),
+	}
+}
+
+func replicationTaskRow(t harbor.ReplicationTaskResult) []string {
+	return []string{
+		strconv.Itoa(t.Id),
+		t.ResourceType,
+		t.SrcResource,
+		t.DstResource,
+		t.Operation,
+		t.Status,
+		t.StartTime,
+		t.EndTime,
+	}
+}
+
+type ReplicationExecutionsState struct {
+	table  table.Model
+	data   []harbor.ReplicationExecutionResult
+	policy ReplicationPolicy
+	prompt PromptState
+	pollId int
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type ReplicationTasksState struct {
+	table     table.Model
+	data      []harbor.ReplicationTaskResult
+	execution harbor.ReplicationExecutionResult
+	// failedOnly hides the tasks that did not fail
+	failedOnly bool
+	log        string
+	pollId     int
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+type replicationExecutionsLoadedMsg struct {
+	executions []harbor.ReplicationExecutionResult
+	err        error
+}
+
+type replicationTasksLoadedMsg struct {
+	execution *harbor.ReplicationExecutionResult
+	tasks     []harbor.ReplicationTaskResult
+	err       error
+}
+
+type replicationTaskLogMsg struct {
+	task harbor.ReplicationTaskResult
+	log  string
+	err  error
+}
+
+type replicationStoppedMsg struct {
+	id  int
+	err error
+}
+
+type replicationExecutionsPollMsg struct {
+	id int
+}
+
+type replicationTasksPollMsg struct {
+	id int
+}
+
+func fetchReplicationExecutions(ctx context.Context, policy ReplicationPolicy) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return replicationExecutionsLoadedMsg{err: err}
+		}
+
+		e, err := harborClient.FetchReplicationExecutions(ctx, policy.Id, replicationExecutionsLimit)
+		if err != nil {
+			slog.Error("Error fetching replication executions", "err", err)
+			return replicationExecutionsLoadedMsg{err: fmt.Errorf("failed to fetch executions of %s: %w", policy.Name, err)}
+		}
+
+		return replicationExecutionsLoadedMsg{executions: *e}
+	}
+}
+
+// fetchReplicationTasks reloads the execution along with its tasks, so its
+// progress is known too.
+func fetchReplicationTasks(ctx context.Context, executionId int) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return replicationTasksLoadedMsg{err: err}
+		}
+
+		e, err := harborClient.FetchReplicationExecution(ctx, executionId)
+		if err != nil {
+			return replicationTasksLoadedMsg{err: fmt.Errorf("failed to fetch execution %d: %w", executionId, err)}
+		}
+
+		t, err := harborClient.FetchReplicationTasks(ctx, executionId)
+		if err != nil {
+			slog.Error("Error fetching replication tasks", "err", err)
+			return replicationTasksLoadedMsg{err: fmt.Errorf("failed to fetch tasks of execution %d: %w", executionId, err)}
+		}
+
+		return replicationTasksLoadedMsg{execution: e, tasks: *t}
+	}
+}
+
+func fetchReplicationTaskLog(ctx context.Context, task harbor.ReplicationTaskResult) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return replicationTaskLogMsg{task: task, err: err}
+		}
+
+		log, err := harborClient.FetchReplicationTaskLog(ctx, task.ExecutionId, task.Id)
+		if err != nil {
+			return replicationTaskLogMsg{task: task, err: fmt.Errorf("failed to fetch log of task %d: %w", task.Id, err)}
+		}
+
+		return replicationTaskLogMsg{task: task, log: log}
+	}
+}
+
+func stopReplication(ctx context.Context, id int) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return replicationStoppedMsg{id: id, err: err}
+		}
+
+		err = harborClient.StopReplication(ctx, id)
+		return replicationStoppedMsg{id: id, err: err}
+	}
+}
+
+// poll starts a new polling loop while an execution is running. Ticks from
+// any previous loop are ignored from now on.
+func (s ReplicationExecutionsState) poll() (ReplicationExecutionsState, tea.Cmd) {
+	s.pollId++
+	if !slices.ContainsFunc(s.data, harbor.ReplicationExecutionResult.IsRunning) {
+		return s, nil
+	}
+
+	id := s.pollId
+	return s, tea.Tick(replicationPollInterval, func(time.Time) tea.Msg {
+		return replicationExecutionsPollMsg{id}
+	})
+}
+
+// poll starts a new polling loop while the execution is running. Ticks from
+// any previous loop are ignored from now on.
+func (s ReplicationTasksState) poll() (ReplicationTasksState, tea.Cmd) {
+	s.pollId++
+	if !s.execution.IsRunning() {
+		return s, nil
+	}
+
+	id := s.pollId
+	return s, tea.Tick(replicationPollInterval, func(time.Time) tea.Msg {
+		return replicationTasksPollMsg{id}
+	})
+}
+
+// visible returns the tasks shown in the table.
+func (s ReplicationTasksState) visible() []harbor.ReplicationTaskResult {
+	if !s.failedOnly {
+		return s.data
+	}
+
+	failed := []harbor.ReplicationTaskResult{}
+	for _, t := range s.data {
+		if t.Status == harbor.ReplicationStatusFailed {
+			failed = append(failed, t)
+		}
+	}
+	return failed
+}
+
+// lastLines keeps the last n lines of text.
+func lastLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m model) replicationExecutionsView() string {
+	state := m.state.replicationExecutions
+	title := sectionTitleStyle.Render(fmt.Sprintf("Executions of %s", state.policy.Name))
+	return withPrompt(lipgloss.JoinVertical(lipgloss.Left, title, state.table.View()), state.prompt)
+}
+
+func (m model) replicationTasksView() string {
+	state := m.state.replicationTasks
+	e := state.execution
+
+	summary := fmt.Sprintf("Execution %d: %s, %d of %d tasks succeeded, %d failed, %d in progress, %d stopped",
+		e.Id, e.Status, e.Succeed, e.Total, e.Failed, e.InProgress, e.Stopped)
+	if e.Failed > 0 {
+		summary = unhealthyStyle.Render(summary)
+	}
+
+	items := []string{sectionTitleStyle.Render(summary)}
+	if e.StatusText != "" {
+		items = append(items, e.StatusText)
+	}
+	items = append(items, state.table.View())
+	if state.log != "" {
+		items = append(items, infoStyle.Render(state.log))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, items...)
+}
+
+func (m model) replicationExecutionsUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	state := m.state.replicationExecutions
+	if state.prompt.handles(msg) {
+		prompt, value, submitted, cmd := state.prompt.update(msg)
+		m.state.replicationExecutions.prompt = prompt
+		if submitted && state.prompt.action == startReplicationPromptAction && value == "yes" {
+			m = m.setInfo(fmt.Sprintf("Starting replication %s", state.policy.Name))
+			return m, startReplication(state.ctx, state.policy)
+		}
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case replicationExecutionsLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.replicationExecutions = newEmptyReplicationExecutionsState(state, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		cursor := state.table.Cursor()
+		state = newReplicationExecutionsState(state, msg.executions)
+		state.table.SetCursor(cursor)
+		m.state.replicationExecutions, cmd = state.poll()
+		return m, cmd
+	case replicationExecutionsPollMsg:
+		if msg.id != state.pollId {
+			return m, nil
+		}
+		return m, fetchReplicationExecutions(state.ctx, state.policy)
+	case replicationStoppedMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(fmt.Errorf("failed to stop execution %d: %w", msg.id, msg.err))
+			}
+			return m, nil
+		}
+		m = m.setInfo(fmt.Sprintf("Stopping execution %d", msg.id))
+		return m, fetchReplicationExecutions(state.ctx, state.policy)
+	case replicationStartedMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+		m = m.setInfo(fmt.Sprintf("Started replication %s, execution %d", msg.policy.Name, msg.executionId))
+		return m, fetchReplicationExecutions(state.ctx, state.policy)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back to the policies, their last run may have changed
+			state.cancel()
+			m.state.replication = newEmptyReplicationState(m.state.replication, "Loading...")
+			m = m.SwitchPage(replicationPage)
+			return m, fetchReplication(m.state.replication.ctx)
+		case "r":
+			// Run the policy again
+			m.state.replicationExecutions.prompt, cmd = confirmReplication(state.policy)
+			return m, cmd
+		case "x":
+			// Stop the execution under the cursor
+			if len(state.data) == 0 {
+				return m, nil
+			}
+			active := state.data[state.table.Cursor()]
+			if !active.IsRunning() {
+				return m, nil
+			}
+			return m, stopReplication(state.ctx, active.Id)
+		case "enter":
+			if len(state.data) == 0 {
+				return m, nil
+			}
+			active := state.data[state.table.Cursor()]
+			m.state.replicationTasks, cmd = m.NewReplicationTasksState(active)
+			m = m.SwitchPage(replicationTasksPage)
+			return m, cmd
+		}
+	}
+
+	m.state.replicationExecutions.table, cmd = state.table.Update(msg)
+	return m, cmd
+}
+
+func (m model) replicationTasksUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	state := m.state.replicationTasks
+
+	switch msg := msg.(type) {
+	case replicationTasksLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.replicationTasks = newEmptyReplicationTasksState(state, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		cursor := state.table.Cursor()
+		state.execution = *msg.execution
+		state = newReplicationTasksState(state, msg.tasks)
+		state.table.SetCursor(cursor)
+		m.state.replicationTasks, cmd = state.poll()
+		return m, cmd
+	case replicationTasksPollMsg:
+		if msg.id != state.pollId {
+			return m, nil
+		}
+		return m, fetchReplicationTasks(state.ctx, state.execution.Id)
+	case replicationTaskLogMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+		m.state.replicationTasks.log = fmt.Sprintf("Log of task %d:\n%s", msg.task.Id, lastLines(msg.log, taskLogLines))
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back to the executions, following them again
+			state.cancel()
+			m = m.SwitchPage(replicationExecutionsPage)
+			executions := m.state.replicationExecutions
+			return m, fetchReplicationExecutions(executions.ctx, executions.policy)
+		case "f":
+			// Show only failed tasks, or every task
+			state.failedOnly = !state.failedOnly
+			m.state.replicationTasks = newReplicationTasksState(state, state.data)
+			return m, nil
+		case "l", "enter":
+			// Show the end of the log of the task under the cursor
+			visible := state.visible()
+			if len(visible) == 0 {
+				return m, nil
+			}
+			return m, fetchReplicationTaskLog(state.ctx, visible[state.table.Cursor()])
+		}
+	}
+
+	m.state.replicationTasks.table, cmd = state.table.Update(msg)
+	return m, cmd
+}
+
+var REPLICATION_EXECUTIONS_COLUMNS = []table.Column{
+	{Title: "Id", Width: 8},
+	{Title: "Status", Width: 12},
+	{Title: "Trigger", Width: 10},
+	{Title: "Start time", Width: 26},
+	{Title: "End time", Width: 26},
+	{Title: "Succeeded", Width: 10},
+	{Title: "Failed", Width: 7},
+	{Title: "Running", Width: 8},
+}
+
+var REPLICATION_TASKS_COLUMNS = []table.Column{
+	{Title: "Id", Width: 8},
+	{Title: "Type", Width: 10},
+	{Title: "Source", Width: 30},
+	{Title: "Destination", Width: 30},
+	{Title: "Operation", Width: 10},
+	{Title: "Status", Width: 12},
+	{Title: "Start time", Width: 26},
+	{Title: "End time", Width: 26},
+}
+
+// newEmptyReplicationExecutionsState keeps the request context of state and
+// shows message instead of data.
+func newEmptyReplicationExecutionsState(state ReplicationExecutionsState, message string) ReplicationExecutionsState {
+	t := table.New(
+		table.WithColumns(REPLICATION_EXECUTIONS_COLUMNS),
+		table.WithRows([]table.Row{{message, "", "", "", "", "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []harbor.ReplicationExecutionResult{}
+
+	return state
+}
+
+func newReplicationExecutionsState(state ReplicationExecutionsState, executions []harbor.ReplicationExecutionResult) ReplicationExecutionsState {
+	rows := make([]table.Row, len(executions))
+	for i, e := range executions {
+		rows[i] = replicationExecutionRow(e)
+	}
+
+	t := table.New(
+		table.WithColumns(REPLICATION_EXECUTIONS_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = executions
+
+	slog.Debug("New Replication executions state created.")
+
+	return state
+}
+
+// newEmptyReplicationTasksState keeps the request context of state and shows
+// message instead of data.
+func newEmptyReplicationTasksState(state ReplicationTasksState, message string) ReplicationTasksState {
+	t := table.New(
+		table.WithColumns(REPLICATION_TASKS_COLUMNS),
+		table.WithRows([]table.Row{{message, "", "", "", "", "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []harbor.ReplicationTaskResult{}
+
+	return state
+}
+
+func newReplicationTasksState(state ReplicationTasksState, tasks []harbor.ReplicationTaskResult) ReplicationTasksState {
+	state.data = tasks
+	visible := state.visible()
+
+	rows := make([]table.Row, len(visible))
+	for i, t := range visible {
+		rows[i] = replicationTaskRow(t)
+	}
+
+	t := table.New(
+		table.WithColumns(REPLICATION_TASKS_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+
+	slog.Debug("New Replication tasks state created.")
+
+	return state
+}
+
+// NewReplicationExecutionsState returns a loading state listing the latest
+// executions of policy along with the command that fetches them.
+func (m model) NewReplicationExecutionsState(policy ReplicationPolicy) (ReplicationExecutionsState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := ReplicationExecutionsState{
+		policy: policy,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	return newEmptyReplicationExecutionsState(state, "Loading..."), fetchReplicationExecutions(ctx, policy)
+}
+
+// NewReplicationTasksState returns a loading state listing the tasks of
+// execution along with the command that fetches them.
+func (m model) NewReplicationTasksState(execution harbor.ReplicationExecutionResult) (ReplicationTasksState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := ReplicationTasksState{
+		execution: execution,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	return newEmptyReplicationTasksState(state, "Loading..."), fetchReplicationTasks(ctx, execution.Id)
+}
@@ -1,8 +1,10 @@
 package tui
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
-	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -18,39 +20,195 @@ type Repository struct {
 	ArtifactsCount int
 }
 
-func (r Repository) ToRow() []string {
-	decodedOnce, _ := url.PathUnescape(r.Name)
+// escapedRepositoryName strips the project from a full repository name, as
+// returned by harbor, and encodes the rest the way the api expects it.
+func escapedRepositoryName(fullName string) string {
+	nameSections := strings.Split(fullName, "/")
+	name := strings.Join(nameSections[1:], "/")
+	// Double encoding needed
+	return url.PathEscape(url.PathEscape(name))
+}
+
+// displayName decodes a double encoded repository name.
+func displayName(name string) string {
+	decodedOnce, _ := url.PathUnescape(name)
 	decodedTwice, _ := url.PathUnescape(decodedOnce)
+	return decodedTwice
+}
+
+func (r Repository) ToRow() []string {
 	columns := []string{
-		decodedTwice,
+		displayName(r.Name),
 		strconv.Itoa(r.ArtifactsCount),
 	}
 	return columns
 }
 
 type RepositoriesState struct {
-	table table.Model
-	data  []Repository
+	table   table.Model
+	data    []Repository
+	project string
+	filter  string
+	prompt  PromptState
+	// deleting is the repository waiting for its deletion to be confirmed
+	deleting Repository
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+type repositoriesLoadedMsg struct {
+	project      string
+	repositories []Repository
+	err          error
+}
+
+func fetchRepositories(ctx context.Context, project string, opts harbor.QueryOptions) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			slog.Error("Error creating harbor client", "err", err)
+			return repositoriesLoadedMsg{project: project, err: err}
+		}
+
+		r, err := harborClient.FetchRepositories(ctx, project, opts)
+		if err != nil {
+			slog.Error("Error fetching repositories", "err", err)
+			return repositoriesLoadedMsg{project: project, err: fmt.Errorf("failed to fetch repositories: %w", err)}
+		}
+
+		repositories := make([]Repository, 0, len(*r))
+		for _, repo := range *r {
+			repository := Repository{
+				Name:           escapedRepositoryName(repo.Name),
+				ArtifactsCount: repo.ArtifactCount,
+				Project:        project,
+			}
+			repositories = append(repositories, repository)
+		}
+
+		return repositoriesLoadedMsg{project: project, repositories: repositories}
+	}
 }
 
 func (m model) repositoriesView() string {
-	return m.state.repositories.table.View()
+	return withPrompt(m.state.repositories.table.View(), m.state.repositories.prompt)
 }
 
 func (m model) repositoriesUpdate(msg tea.Msg) (model, tea.Cmd) {
 	var cmd tea.Cmd
+	if m.state.repositories.prompt.handles(msg) {
+		action := m.state.repositories.prompt.action
+		prompt, value, submitted, cmd := m.state.repositories.prompt.update(msg)
+		m.state.repositories.prompt = prompt
+		if submitted && action == packageSearchPromptAction && value != "" {
+			m.state.packageSearch, cmd = m.NewPackageSearchState(m.state.repositories.project, nil, value, repositoriesPage)
+			m = m.SwitchPage(packageSearchPage)
+			return m, cmd
+		}
+		if submitted && action == deleteRepositoryPromptAction {
+			deleting := m.state.repositories.deleting
+			if value != displayName(deleting.Name) {
+				m = m.setInfo("Repository not deleted, the name did not match")
+				return m, nil
+			}
+			m = m.setInfo(fmt.Sprintf("Deleting repository %s", value))
+			return m, deleteRepository(m.state.repositories.ctx, deleting)
+		}
+		if submitted && action == filterPromptAction {
+			state := m.state.repositories
+			m.state.repositories.filter = value
+			m.state.repositories = newEmptyRepositoriesState(m.state.repositories, "Loading...")
+			return m, fetchRepositories(state.ctx, state.project, harbor.QueryOptions{Name: value})
+		}
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
+	case repositoriesLoadedMsg:
+		if msg.project != m.state.repositories.project {
+			// Late result of a project the user already left
+			return m, nil
+		}
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.repositories = newEmptyRepositoriesState(m.state.repositories, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		m.state.repositories = newRepositoriesState(m.state.repositories, msg.repositories)
+		return m, nil
+	case repositoryUsageMsg, repositoryDeletedMsg:
+		return m.repositoryDeleteUpdate(msg)
+	case projectScanMsg:
+		if msg.err != nil && !errors.Is(msg.err, context.Canceled) {
+			slog.Error("Error scanning project", "err", msg.err)
+			m = m.setError(fmt.Errorf("requested scan of %d artifacts in %s, some failed: %w", msg.scanned, msg.project, msg.err))
+			return m, nil
+		}
+
+		m = m.setInfo(fmt.Sprintf("Requested scan of %d artifacts in %s", msg.scanned, msg.project))
+		return m, nil
 	case tea.KeyMsg:
 		switch msg.String() {
+		case "esc":
+			m.state.repositories.cancel()
+			m.state.repositories.ctx, m.state.repositories.cancel = newPageContext()
+			if len(m.state.repositories.data) == 0 {
+				m.state.repositories = newEmptyRepositoriesState(m.state.repositories, "Cancelled, press u to reload")
+			}
+			m = m.setInfo("Cancelled pending requests")
+			return m, nil
+		case "u":
+			// Refresh
+			state := m.state.repositories
+			m.state.repositories = newEmptyRepositoriesState(state, "Loading...")
+			return m, fetchRepositories(state.ctx, state.project, harbor.QueryOptions{Name: state.filter})
+		case "/":
+			m.state.repositories.prompt, cmd = newPrompt("Filter repositories", m.state.repositories.filter, filterPromptAction)
+			return m, cmd
+		case "P":
+			// Search a package in the SBOMs of every repository
+			m.state.repositories.prompt, cmd = newPrompt("Search package (name@version)", "", packageSearchPromptAction)
+			return m, cmd
+		case "S":
+			// Scan every artifact of the project
+			state := m.state.repositories
+			m = m.setInfo(fmt.Sprintf("Requesting scan of every artifact in %s", state.project))
+			return m, scanProject(state.ctx, state.project)
+		case "A":
+			// Show the history of the repository
+			if len(m.state.repositories.data) == 0 {
+				return m, nil
+			}
+			active := m.state.repositories.data[m.state.repositories.table.Cursor()]
+			scope := fmt.Sprintf("%s/%s", active.Project, displayName(active.Name))
+			m.state.audit, cmd = m.NewAuditState(active.Project, scope, repositoriesPage)
+			m = m.SwitchPage(auditPage)
+			return m, cmd
+		case "D":
+			// Delete the repository once its content and usage are known
+			if len(m.state.repositories.data) == 0 {
+				return m, nil
+			}
+			active := m.state.repositories.data[m.state.repositories.table.Cursor()]
+			m = m.setInfo(fmt.Sprintf("Checking what %s holds before deleting it", displayName(active.Name)))
+			return m, fetchRepositoryUsage(m.state.repositories.ctx, active)
 		case "-":
+			m.state.repositories.cancel()
 			m = m.SwitchPage(projectsPage)
 			return m, nil
 		case "enter":
+			if len(m.state.repositories.data) == 0 {
+				return m, nil
+			}
 			rowIndex := m.state.repositories.table.Cursor()
 			active := m.state.repositories.data[rowIndex]
-			m.state.artifacts = m.NewArtifactsState(active.Project, active.Name)
+			m.state.artifacts, cmd = m.NewArtifactsState(active.Project, active.Name, "")
 			m = m.SwitchPage(artifactsPage)
-			return m, nil
+			return m, cmd
 		}
 	}
 	m.state.repositories.table, cmd = m.state.repositories.table.Update(msg)
@@ -62,57 +220,26 @@ var REPOSITORIES_COLUMNS = []table.Column{
 	{Title: "Artifacts count", Width: 15},
 }
 
-func newEmptyRepositoriesState() RepositoriesState {
+// newEmptyRepositoriesState keeps the request context of state and shows
+// message instead of data.
+func newEmptyRepositoriesState(state RepositoriesState, message string) RepositoriesState {
 	t := table.New(
 		table.WithColumns(REPOSITORIES_COLUMNS),
-		table.WithRows([]table.Row{{"No data available", ""}}),
+		table.WithRows([]table.Row{{message, ""}}),
 		table.WithFocused(true),
 		table.WithHeight(2),
 	)
 
 	t.SetStyles(GetTableDefaultStyles())
 
-	state := RepositoriesState{
-		table: t,
-		data:  []Repository{},
-	}
+	state.table = t
+	state.data = []Repository{}
 
 	return state
 }
 
-func (m model) NewRepositoriesState(project string) RepositoriesState {
-	harborClient, err := harbor.NewHarborApiClient(http.DefaultClient)
-	if err != nil {
-		slog.Error("Error creating harbor client", "err", err)
-		return newEmptyRepositoriesState()
-	}
-
-	r, err := harborClient.FetchRepositories(project)
-	if err != nil {
-		slog.Error("Error fetching repositories", "err", err)
-		return newEmptyRepositoriesState()
-	}
-
-	repositories := make([]Repository, len(*r))
-	for i, repo := range *r {
-		nameSections := strings.Split(repo.Name, "/")
-		if len(nameSections) == 0 {
-			// IDK what to do in this scenario
-			continue
-		}
-
-		name := strings.Join(nameSections[1:], "/")
-		// Double encoding needed
-		escapedName := url.PathEscape(url.PathEscape(name))
-		repository := Repository{
-			Name:           escapedName,
-			ArtifactsCount: repo.ArtifactCount,
-			Project:        project,
-		}
-		repositories[i] = repository
-	}
-
-	rows := make([]table.Row, len(*r))
+func newRepositoriesState(state RepositoriesState, repositories []Repository) RepositoriesState {
+	rows := make([]table.Row, len(repositories))
 	for i, r := range repositories {
 		rows[i] = r.ToRow()
 	}
@@ -126,12 +253,23 @@ func (m model) NewRepositoriesState(project string) RepositoriesState {
 
 	t.SetStyles(GetTableDefaultStyles())
 
-	state := RepositoriesState{
-		table: t,
-		data:  repositories,
-	}
+	state.table = t
+	state.data = repositories
 
 	slog.Debug("New Repository state created.")
 
 	return state
 }
+
+// NewRepositoriesState returns a loading repositories state for project
+// along with the command that fetches its data.
+func (m model) NewRepositoriesState(project string) (RepositoriesState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := RepositoriesState{
+		project: project,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	return newEmptyRepositoriesState(state, "Loading..."), fetchRepositories(ctx, project, harbor.QueryOptions{})
+}
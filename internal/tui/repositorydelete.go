@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+// repositoryUsageMsg describes what deleting a repository would destroy.
+type repositoryUsageMsg struct {
+	repository Repository
+	artifacts  int
+	size       int64
+	// running lists the containers still using one of its digests
+	running      []string
+	portainerErr error
+	err          error
+}
+
+type repositoryDeletedMsg struct {
+	repository Repository
+	err        error
+}
+
+// fetchRepositoryUsage counts the artifacts of repository, including the
+// platform manifests of indexes, and looks for containers running them.
+func fetchRepositoryUsage(ctx context.Context, repository Repository) tea.Cmd {
+	return func() tea.Msg {
+		msg := repositoryUsageMsg{repository: repository}
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+
+		a, err := harborClient.FetchArtifacts(ctx, repository.Project, repository.Name, harbor.QueryOptions{})
+		if err != nil {
+			msg.err = fmt.Errorf("failed to fetch artifacts of %s: %w", displayName(repository.Name), err)
+			return msg
+		}
+
+		sizes := map[string]int64{}
+		for _, ar := range *a {
+			sizes[ar.Digest] = int64(ar.Size)
+			if !ar.IsIndex() {
+				continue
+			}
+
+			c, err := harborClient.FetchChildArtifacts(ctx, repository.Project, repository.Name, ar)
+			if err != nil {
+				msg.err = fmt.Errorf("failed to fetch artifacts of index %s: %w", ar.Digest, err)
+				return msg
+			}
+			for _, child := range *c {
+				sizes[child.Digest] = int64(child.Size)
+			}
+		}
+
+		msg.artifacts = len(*a)
+		digests := make([]string, 0, len(sizes))
+		for digest, size := range sizes {
+			msg.size += size
+			digests = append(digests, digest)
+		}
+
+		containers, err := FindContainersOfImages(ctx, digests)
+		if err != nil {
+			slog.Error("Error searching for image usage", "err", err)
+			msg.portainerErr = err
+			return msg
+		}
+
+		for _, found := range containers {
+			for _, c := range found {
+				msg.running = append(msg.running, strings.Join(c.Names, ", "))
+			}
+		}
+
+		return msg
+	}
+}
+
+func deleteRepository(ctx context.Context, repository Repository) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return repositoryDeletedMsg{repository: repository, err: err}
+		}
+
+		if err := harborClient.DeleteRepository(ctx, repository.Project, repository.Name); err != nil {
+			slog.Error("Error deleting repository", "err", err)
+			return repositoryDeletedMsg{
+				repository: repository,
+				err:        fmt.Errorf("failed to delete repository %s: %w", displayName(repository.Name), err),
+			}
+		}
+
+		return repositoryDeletedMsg{repository: repository}
+	}
+}
+
+// deleteRepositoryLabel summarizes what deleting the repository of msg
+// destroys and asks for its name as confirmation.
+func deleteRepositoryLabel(msg repositoryUsageMsg) string {
+	name := displayName(msg.repository.Name)
+	running := "not running in Portainer"
+	if msg.portainerErr != nil {
+		running = fmt.Sprintf("usage in Portainer unknown (%v)", msg.portainerErr)
+	} else if len(msg.running) > 0 {
+		running = fmt.Sprintf("STILL RUNNING in %s", strings.Join(msg.running, "; "))
+	}
+
+	return fmt.Sprintf("Delete %s: %d artifacts, %s, %s? Type its name to confirm", name, msg.artifacts, formatBytes(msg.size), running)
+}
+
+func (m model) repositoryDeleteUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case repositoryUsageMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		m.state.repositories.deleting = msg.repository
+		m = m.setInfo("")
+		m.state.repositories.prompt, cmd = newPrompt(deleteRepositoryLabel(msg), "", deleteRepositoryPromptAction)
+		return m, cmd
+	case repositoryDeletedMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		state := m.state.repositories
+		m = m.setInfo(fmt.Sprintf("Repository %s deleted", displayName(msg.repository.Name)))
+		m.state.repositories = newEmptyRepositoriesState(state, "Loading...")
+		return m, fetchRepositories(state.ctx, state.project, harbor.QueryOptions{Name: state.filter})
+	}
+
+	return m, nil
+}
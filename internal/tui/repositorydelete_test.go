@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDeleteRepositoryLabel(t *testing.T) {
+	repository := Repository{Project: "library", Name: "team%252Fapp"}
+
+	tests := []struct {
+		name string
+		msg  repositoryUsageMsg
+		want string
+	}{
+		{
+			name: "unused",
+			msg:  repositoryUsageMsg{repository: repository, artifacts: 2, size: 3 << 20},
+			want: "Delete team/app: 2 artifacts, 3.00 MiB, not running in Portainer? Type its name to confirm",
+		},
+		{
+			name: "running",
+			msg:  repositoryUsageMsg{repository: repository, artifacts: 1, running: []string{"/web-1", "/web-2"}},
+			want: "Delete team/app: 1 artifacts, 0.00 B, STILL RUNNING in /web-1; /web-2? Type its name to confirm",
+		},
+		{
+			name: "portainer unreachable",
+			msg:  repositoryUsageMsg{repository: repository, portainerErr: errors.New("timeout")},
+			want: "Delete team/app: 0 artifacts, 0.00 B, usage in Portainer unknown (timeout)? Type its name to confirm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deleteRepositoryLabel(tt.msg); got != tt.want {
+				t.Errorf("deleteRepositoryLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRepositoryNeedsItsName(t *testing.T) {
+	repository := Repository{Project: "library", Name: "team%252Fapp"}
+
+	tests := []struct {
+		typed      string
+		wantDelete bool
+	}{
+		{typed: "app", wantDelete: false},
+		{typed: "team%252Fapp", wantDelete: false},
+		{typed: "team/app", wantDelete: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typed, func(t *testing.T) {
+			m := model{}
+			m.state.repositories = newRepositoriesState(RepositoriesState{project: "library"}, []Repository{repository})
+			m, _ = m.repositoryDeleteUpdate(repositoryUsageMsg{repository: repository})
+			if !m.state.repositories.prompt.active() {
+				t.Fatal("deletion not confirmed by the user")
+			}
+
+			m, _ = m.repositoriesUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.typed)})
+			m, cmd := m.repositoriesUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+			if got := cmd != nil; got != tt.wantDelete {
+				t.Errorf("typing %q deleted = %t, want %t", tt.typed, got, tt.wantDelete)
+			}
+			if !tt.wantDelete && !strings.Contains(m.state.footer.message, "not deleted") {
+				t.Errorf("footer = %q, want the reason for not deleting", m.state.footer.message)
+			}
+		})
+	}
+}
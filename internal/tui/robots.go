@@ -0,0 +1,518 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+// defaultRobotExpiryWindow is how soon a robot must expire to be
+// highlighted, unless HARBORW_ROBOT_EXPIRY_DAYS says otherwise.
+const defaultRobotExpiryWindow = 14 * 24 * time.Hour
+
+var (
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	secretStyle  = lipgloss.NewStyle().Bold(true).Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("214")).Padding(0, 1)
+)
+
+// robotExpiryWindowFromEnv reads the expiry window, in days, from
+// HARBORW_ROBOT_EXPIRY_DAYS.
+func robotExpiryWindowFromEnv() time.Duration {
+	value := os.Getenv("HARBORW_ROBOT_EXPIRY_DAYS")
+	if value == "" {
+		return defaultRobotExpiryWindow
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		slog.Warn("Ignoring invalid HARBORW_ROBOT_EXPIRY_DAYS", "value", value)
+		return defaultRobotExpiryWindow
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// robotExpiresWithin reports whether robot expires, or already expired,
+// before now+window.
+func robotExpiresWithin(robot harbor.RobotResult, now time.Time, window time.Duration) bool {
+	expires, ok := robot.Expires()
+	return ok && expires.Before(now.Add(window))
+}
+
+// formatRemaining renders how long until t, e.g. "3d 4h" or "expired".
+func formatRemaining(t time.Time, now time.Time) string {
+	d := t.Sub(now)
+	if d <= 0 {
+		return "expired"
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d%(24*time.Hour)) / int(time.Hour)
+	if days == 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dd %dh", days, hours)
+}
+
+func robotRow(robot harbor.RobotResult, now time.Time, window time.Duration) []string {
+	disabled := "no"
+	if robot.Disable {
+		disabled = "yes"
+	}
+
+	expires, remaining := "never", ""
+	if t, ok := robot.Expires(); ok {
+		expires = t.Format(time.DateTime)
+		remaining = formatRemaining(t, now)
+		if robotExpiresWithin(robot, now, window) {
+			remaining = "! " + remaining
+		}
+	}
+
+	return []string{
+		robot.Name,
+		robot.Description,
+		disabled,
+		expires,
+		remaining,
+	}
+}
+
+type RobotsState struct {
+	table table.Model
+	data  []harbor.RobotResult
+	// project is nil when managing system robots
+	project *Project
+	// home is the project the page was opened for
+	home   *Project
+	window time.Duration
+	prompt PromptState
+	from   page
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type robotsLoadedMsg struct {
+	robots []harbor.RobotResult
+	err    error
+}
+
+// robotSecretMsg carries the secret of a robot that was just created or
+// whose secret was refreshed. It is handled by the root model whatever the
+// current page, as harbor never returns that secret again.
+type robotSecretMsg struct {
+	name   string
+	secret string
+	err    error
+}
+
+type robotUpdatedMsg struct {
+	message string
+	err     error
+}
+
+func fetchRobots(ctx context.Context, project *Project) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return robotsLoadedMsg{err: err}
+		}
+
+		projectId := 0
+		if project != nil {
+			projectId = project.Id
+		}
+
+		r, err := harborClient.FetchRobots(ctx, projectId)
+		if err != nil {
+			slog.Error("Error fetching robots", "err", err)
+			return robotsLoadedMsg{err: fmt.Errorf("failed to fetch robot accounts: %w", err)}
+		}
+
+		return robotsLoadedMsg{robots: *r}
+	}
+}
+
+// parseRobotRequest reads "name [days]" into a robot able to pull and push
+// the repositories of project, or of every project for system robots.
+// Without days the robot never expires.
+func parseRobotRequest(value string, project *Project) (harbor.RobotCreateRequestBody, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 || len(fields) > 2 {
+		return harbor.RobotCreateRequestBody{}, fmt.Errorf("invalid robot %q, expected name [days]", value)
+	}
+
+	duration := harbor.RobotNeverExpires
+	if len(fields) == 2 {
+		days, err := strconv.Atoi(fields[1])
+		if err != nil || (days <= 0 && days != harbor.RobotNeverExpires) {
+			return harbor.RobotCreateRequestBody{}, fmt.Errorf("invalid duration %q, expected days or -1", fields[1])
+		}
+		duration = days
+	}
+
+	level, namespace := harbor.RobotLevelSystem, "*"
+	if project != nil {
+		level, namespace = harbor.RobotLevelProject, project.Name
+	}
+
+	return harbor.RobotCreateRequestBody{
+		Name:        fields[0],
+		Description: "Created with harborw",
+		Level:       level,
+		Duration:    duration,
+		Permissions: []harbor.RobotPermission{{
+			Kind:      "project",
+			Namespace: namespace,
+			Access: []harbor.RobotAccess{
+				{Resource: "repository", Action: "pull"},
+				{Resource: "repository", Action: "push"},
+			},
+		}},
+	}, nil
+}
+
+// createRobot creates robot. Leaving the page does not cancel the request,
+// the secret of a robot created anyway would be lost.
+func createRobot(ctx context.Context, robot harbor.RobotCreateRequestBody) tea.Cmd {
+	ctx = context.WithoutCancel(ctx)
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return robotSecretMsg{name: robot.Name, err: err}
+		}
+
+		created, err := harborClient.CreateRobot(ctx, robot)
+		if err != nil {
+			slog.Error("Error creating robot", "err", err)
+			return robotSecretMsg{name: robot.Name, err: fmt.Errorf("failed to create robot %s: %w", robot.Name, err)}
+		}
+
+		return robotSecretMsg{name: created.Name, secret: created.Secret}
+	}
+}
+
+// refreshRobotSecret replaces the secret of robot. Like createRobot, the
+// request is not cancelled when leaving the page.
+func refreshRobotSecret(ctx context.Context, robot harbor.RobotResult) tea.Cmd {
+	ctx = context.WithoutCancel(ctx)
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return robotSecretMsg{name: robot.Name, err: err}
+		}
+
+		secret, err := harborClient.RefreshRobotSecret(ctx, robot.Id)
+		if err != nil {
+			slog.Error("Error refreshing robot secret", "err", err)
+			return robotSecretMsg{name: robot.Name, err: fmt.Errorf("failed to refresh the secret of %s: %w", robot.Name, err)}
+		}
+
+		return robotSecretMsg{name: robot.Name, secret: secret}
+	}
+}
+
+// toggleRobot disables robot, or enables it back when disabled.
+func toggleRobot(ctx context.Context, robot harbor.RobotResult) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return robotUpdatedMsg{err: err}
+		}
+
+		robot.Disable = !robot.Disable
+		verb := "enable"
+		if robot.Disable {
+			verb = "disable"
+		}
+
+		if err := harborClient.UpdateRobot(ctx, robot); err != nil {
+			slog.Error("Error updating robot", "err", err)
+			return robotUpdatedMsg{err: fmt.Errorf("failed to %s %s: %w", verb, robot.Name, err)}
+		}
+
+		return robotUpdatedMsg{message: fmt.Sprintf("Robot %s %sd", robot.Name, verb)}
+	}
+}
+
+func deleteRobot(ctx context.Context, robot harbor.RobotResult) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return robotUpdatedMsg{err: err}
+		}
+
+		if err := harborClient.DeleteRobot(ctx, robot.Id); err != nil {
+			slog.Error("Error deleting robot", "err", err)
+			return robotUpdatedMsg{err: fmt.Errorf("failed to delete %s: %w", robot.Name, err)}
+		}
+
+		return robotUpdatedMsg{message: fmt.Sprintf("Robot %s deleted", robot.Name)}
+	}
+}
+
+// expiringView warns about the robots expiring within the window.
+func (s RobotsState) expiringView(now time.Time) string {
+	expiring := []string{}
+	for _, r := range s.data {
+		if !r.Disable && robotExpiresWithin(r, now, s.window) {
+			expiring = append(expiring, r.Name)
+		}
+	}
+
+	days := int(s.window / (24 * time.Hour))
+	if len(expiring) == 0 {
+		return infoStyle.Render(fmt.Sprintf("No robot expires within %d days", days))
+	}
+
+	return warningStyle.Render(fmt.Sprintf("%d robots expire within %d days: %s", len(expiring), days, strings.Join(expiring, ", ")))
+}
+
+func (s RobotsState) title() string {
+	if s.project == nil {
+		return "System robot accounts"
+	}
+	return fmt.Sprintf("Robot accounts of project %s", s.project.Name)
+}
+
+func (m model) robotsView() string {
+	state := m.state.robots
+	items := []string{sectionTitleStyle.Render(state.title()), state.table.View(), state.expiringView(time.Now())}
+
+	return withPrompt(lipgloss.JoinVertical(lipgloss.Left, items...), state.prompt)
+}
+
+// reloadRobots fetches the robot accounts again, e.g. after one changed.
+func (m model) reloadRobots() (model, tea.Cmd) {
+	state := m.state.robots
+	m.state.robots = newEmptyRobotsState(state, "Loading...")
+	return m, fetchRobots(state.ctx, state.project)
+}
+
+func (m model) robotsUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.state.robots.prompt.handles(msg) {
+		action := m.state.robots.prompt.action
+		prompt, value, submitted, cmd := m.state.robots.prompt.update(msg)
+		m.state.robots.prompt = prompt
+		if !submitted {
+			return m, cmd
+		}
+
+		state := m.state.robots
+		switch action {
+		case createRobotPromptAction:
+			robot, err := parseRobotRequest(value, state.project)
+			if err != nil {
+				m = m.setError(err)
+				return m, nil
+			}
+			return m, createRobot(state.ctx, robot)
+		case robotExpiryWindowPromptAction:
+			days, err := strconv.Atoi(value)
+			if err != nil || days < 0 {
+				m = m.setError(fmt.Errorf("invalid number of days %q", value))
+				return m, nil
+			}
+			state.window = time.Duration(days) * 24 * time.Hour
+			m.state.robots = newRobotsState(state, state.data)
+			return m, nil
+		case refreshRobotSecretPromptAction, deleteRobotPromptAction, disableRobotPromptAction:
+			if value != "yes" || len(state.data) == 0 {
+				return m, nil
+			}
+			active := state.data[state.table.Cursor()]
+			switch action {
+			case deleteRobotPromptAction:
+				return m, deleteRobot(state.ctx, active)
+			case disableRobotPromptAction:
+				return m, toggleRobot(state.ctx, active)
+			}
+			return m, refreshRobotSecret(state.ctx, active)
+		}
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case robotsLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.robots = newEmptyRobotsState(m.state.robots, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		m.state.robots = newRobotsState(m.state.robots, msg.robots)
+		return m, nil
+	case robotUpdatedMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		m = m.setInfo(msg.message)
+		return m.reloadRobots()
+	case tea.KeyMsg:
+		state := m.state.robots
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			state.cancel()
+			m = m.SwitchPage(state.from)
+			return m, nil
+		case "g":
+			// Switch between the project and the system robot accounts
+			if state.home == nil {
+				return m, nil
+			}
+			if state.project == nil {
+				m.state.robots.project = state.home
+			} else {
+				m.state.robots.project = nil
+			}
+			return m.reloadRobots()
+		case "w":
+			days := strconv.Itoa(int(state.window / (24 * time.Hour)))
+			m.state.robots.prompt, cmd = newPrompt("Highlight robots expiring within (days)", days, robotExpiryWindowPromptAction)
+			return m, cmd
+		case "n":
+			m.state.robots.prompt, cmd = newPrompt("New robot (name [days], pulls and pushes)", "", createRobotPromptAction)
+			return m, cmd
+		}
+
+		if len(state.data) == 0 {
+			break
+		}
+		active := state.data[state.table.Cursor()]
+		switch msg.String() {
+		case "s":
+			// Refresh the secret, the current one stops working
+			label := fmt.Sprintf("Refresh the secret of %s? Its current secret stops working. Type yes to confirm", active.Name)
+			m.state.robots.prompt, cmd = newPrompt(label, "", refreshRobotSecretPromptAction)
+			return m, cmd
+		case "d":
+			// Disable the robot once confirmed, its pipelines stop working, or
+			// enable it back
+			if active.Disable {
+				return m, toggleRobot(state.ctx, active)
+			}
+			label := fmt.Sprintf("Disable robot %s? Everything using it loses access. Type yes to confirm", active.Name)
+			m.state.robots.prompt, cmd = newPrompt(label, "", disableRobotPromptAction)
+			return m, cmd
+		case "D":
+			label := fmt.Sprintf("Delete robot %s? Type yes to confirm", active.Name)
+			m.state.robots.prompt, cmd = newPrompt(label, "", deleteRobotPromptAction)
+			return m, cmd
+		}
+	}
+
+	m.state.robots.table, cmd = m.state.robots.table.Update(msg)
+	return m, cmd
+}
+
+// robotSecretUpdate keeps the new secret of a robot on screen, whatever the
+// page, until the user dismisses it.
+func (m model) robotSecretUpdate(msg robotSecretMsg) (model, tea.Cmd) {
+	if msg.err != nil {
+		if !errors.Is(msg.err, context.Canceled) {
+			m = m.setError(msg.err)
+		}
+		return m, nil
+	}
+
+	m.state.robotSecret = fmt.Sprintf("Secret of %s, it will not be shown again, press ctrl+x once saved:\n%s", msg.name, msg.secret)
+	m = m.setInfo(fmt.Sprintf("New secret for %s, copy it now", msg.name))
+	if m.page != robotsPage {
+		return m, nil
+	}
+	return m.reloadRobots()
+}
+
+// robotSecretView renders the pending robot secret, if any.
+func (m model) robotSecretView() string {
+	if m.state.robotSecret == "" {
+		return ""
+	}
+	return secretStyle.Render(m.state.robotSecret)
+}
+
+var ROBOTS_COLUMNS = []table.Column{
+	{Title: "Name", Width: 40},
+	{Title: "Description", Width: 30},
+	{Title: "Disabled", Width: 8},
+	{Title: "Expires", Width: 20},
+	{Title: "Remaining", Width: 12},
+}
+
+// newEmptyRobotsState keeps the request context of state and shows message
+// instead of data.
+func newEmptyRobotsState(state RobotsState, message string) RobotsState {
+	t := table.New(
+		table.WithColumns(ROBOTS_COLUMNS),
+		table.WithRows([]table.Row{{message, "", "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []harbor.RobotResult{}
+
+	return state
+}
+
+func newRobotsState(state RobotsState, robots []harbor.RobotResult) RobotsState {
+	now := time.Now()
+	rows := make([]table.Row, len(robots))
+	for i, r := range robots {
+		rows[i] = robotRow(r, now, state.window)
+	}
+
+	t := table.New(
+		table.WithColumns(ROBOTS_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(18),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = robots
+
+	slog.Debug("New Robots state created.")
+
+	return state
+}
+
+// NewRobotsState returns a loading state with the robot accounts of
+// project, or the system ones when project is nil, along with the command
+// that fetches them. from is the page to return to.
+func (m model) NewRobotsState(project *Project, from page) (RobotsState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := RobotsState{
+		project: project,
+		home:    project,
+		window:  robotExpiryWindowFromEnv(),
+		from:    from,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	return newEmptyRobotsState(state, "Loading..."), fetchRobots(ctx, project)
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestParseRobotRequest(t *testing.T) {
+	project := &Project{Id: 1, Name: "library"}
+
+	tests := []struct {
+		name          string
+		value         string
+		project       *Project
+		wantName      string
+		wantLevel     string
+		wantNamespace string
+		wantDuration  int
+		wantErr       bool
+	}{
+		{name: "project robot", value: "ci", project: project, wantName: "ci", wantLevel: harbor.RobotLevelProject, wantNamespace: "library", wantDuration: harbor.RobotNeverExpires},
+		{name: "system robot", value: "ci", project: nil, wantName: "ci", wantLevel: harbor.RobotLevelSystem, wantNamespace: "*", wantDuration: harbor.RobotNeverExpires},
+		{name: "with days", value: "ci 30", project: project, wantName: "ci", wantLevel: harbor.RobotLevelProject, wantNamespace: "library", wantDuration: 30},
+		{name: "never expires", value: " ci  -1 ", project: project, wantName: "ci", wantLevel: harbor.RobotLevelProject, wantNamespace: "library", wantDuration: harbor.RobotNeverExpires},
+		{name: "empty", value: "  ", project: project, wantErr: true},
+		{name: "too many fields", value: "ci 30 days", project: project, wantErr: true},
+		{name: "zero days", value: "ci 0", project: project, wantErr: true},
+		{name: "negative days", value: "ci -7", project: project, wantErr: true},
+		{name: "days not a number", value: "ci month", project: project, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRobotRequest(tt.value, tt.project)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRobotRequest(%q) error = %v, wantErr %t", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.Name != tt.wantName || got.Level != tt.wantLevel || got.Duration != tt.wantDuration {
+				t.Errorf("parseRobotRequest(%q) = %s %s %d, want %s %s %d", tt.value, got.Name, got.Level, got.Duration, tt.wantName, tt.wantLevel, tt.wantDuration)
+			}
+			if len(got.Permissions) != 1 || got.Permissions[0].Namespace != tt.wantNamespace {
+				t.Fatalf("parseRobotRequest(%q) permissions = %+v, want one on %s", tt.value, got.Permissions, tt.wantNamespace)
+			}
+			actions := map[string]bool{}
+			for _, a := range got.Permissions[0].Access {
+				if a.Resource == "repository" {
+					actions[a.Action] = true
+				}
+			}
+			if !actions["pull"] || !actions["push"] {
+				t.Errorf("parseRobotRequest(%q) access = %+v, want pull and push", tt.value, got.Permissions[0].Access)
+			}
+		})
+	}
+}
+
+func TestRobotExpiresWithin(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	window := 14 * 24 * time.Hour
+	at := func(d time.Duration) int64 { return now.Add(d).Unix() }
+
+	tests := []struct {
+		name      string
+		expiresAt int64
+		want      bool
+	}{
+		{name: "never expires", expiresAt: harbor.RobotNeverExpires, want: false},
+		{name: "no expiry", expiresAt: 0, want: false},
+		{name: "already expired", expiresAt: at(-time.Hour), want: true},
+		{name: "within the window", expiresAt: at(3 * 24 * time.Hour), want: true},
+		{name: "just before the end of the window", expiresAt: at(window - time.Second), want: true},
+		{name: "at the end of the window", expiresAt: at(window), want: false},
+		{name: "after the window", expiresAt: at(30 * 24 * time.Hour), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			robot := harbor.RobotResult{Name: "robot$ci", ExpiresAt: tt.expiresAt}
+			if got := robotExpiresWithin(robot, now, window); got != tt.want {
+				t.Errorf("robotExpiresWithin() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
 )
 
 type page int
@@ -12,27 +13,100 @@ const (
 	projectsPage
 	repositoriesPage
 	artifactsPage
+	vulnerabilitiesPage
+	buildHistoryPage
+	inspectPage
+	accessoriesPage
+	sbomPage
+	packageSearchPage
+	searchPage
 	statusPage
+	projectFormPage
+	replicationPage
+	replicationExecutionsPage
+	replicationTasksPage
+	auditPage
+	robotsPage
+	membersPage
+	webhooksPage
+	webhookDeliveriesPage
+	webhookFormPage
 )
 
 type state struct {
-	projects     ProjectsState
-	repositories RepositoriesState
-	artifacts    ArtifactsState
+	projects              ProjectsState
+	repositories          RepositoriesState
+	artifacts             ArtifactsState
+	vulnerabilities       VulnerabilitiesState
+	buildHistory          BuildHistoryState
+	inspect               InspectState
+	accessories           AccessoriesState
+	sbom                  SbomState
+	packageSearch         PackageSearchState
+	search                SearchState
+	status                StatusState
+	projectForm           ProjectFormState
+	replication           ReplicationState
+	replicationExecutions ReplicationExecutionsState
+	replicationTasks      ReplicationTasksState
+	audit                 AuditState
+	robots                RobotsState
+	members               MembersState
+	webhooks              WebhooksState
+	webhookDeliveries     WebhookDeliveriesState
+	webhookForm           WebhookFormState
+	footer                FooterState
+	// robotSecret is the secret of a robot shown until dismissed
+	robotSecret string
 }
 
 type model struct {
 	page     page
 	state    state
 	renderer *lipgloss.Renderer
+	init     tea.Cmd
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	return m.init
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
+	if msg, ok := msg.(robotSecretMsg); ok {
+		// The secret must not be lost, whatever the page or prompt shown
+		return m.robotSecretUpdate(msg)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+x" && m.state.robotSecret != "" {
+		m.state.robotSecret = ""
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+f" && !m.isTyping() && m.page != searchPage {
+		// Search is reachable from every page
+		m = m.leavePage()
+		m.state.search, cmd = m.NewSearchState(m.page)
+		m = m.SwitchPage(searchPage)
+		return m, cmd
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+t" && !m.isTyping() && m.page != statusPage {
+		// Harbor and portainer status is reachable from every page
+		m = m.leavePage()
+		m.state.status, cmd = m.NewStatusState(m.page)
+		m = m.SwitchPage(statusPage)
+		return m, cmd
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+r" && !m.isTyping() && !m.isReplicationPage() {
+		// Replication is reachable from every page
+		m = m.leavePage()
+		m.state.replication, cmd = m.NewReplicationState(m.page)
+		m = m.SwitchPage(replicationPage)
+		return m, cmd
+	}
+
 	switch m.page {
 	case menuPage:
 		m, cmd = m.menuUpdate(msg)
@@ -42,15 +116,53 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m, cmd = m.repositoriesUpdate(msg)
 	case artifactsPage:
 		m, cmd = m.artifactsUpdate(msg)
+	case vulnerabilitiesPage:
+		m, cmd = m.vulnerabilitiesUpdate(msg)
+	case buildHistoryPage:
+		m, cmd = m.buildHistoryUpdate(msg)
+	case inspectPage:
+		m, cmd = m.inspectUpdate(msg)
+	case accessoriesPage:
+		m, cmd = m.accessoriesUpdate(msg)
+	case sbomPage:
+		m, cmd = m.sbomUpdate(msg)
+	case packageSearchPage:
+		m, cmd = m.packageSearchUpdate(msg)
+	case searchPage:
+		m, cmd = m.searchUpdate(msg)
 	case statusPage:
 		m, cmd = m.statusUpdate(msg)
+	case projectFormPage:
+		m, cmd = m.projectFormUpdate(msg)
+	case replicationPage:
+		m, cmd = m.replicationUpdate(msg)
+	case replicationExecutionsPage:
+		m, cmd = m.replicationExecutionsUpdate(msg)
+	case replicationTasksPage:
+		m, cmd = m.replicationTasksUpdate(msg)
+	case auditPage:
+		m, cmd = m.auditUpdate(msg)
+	case robotsPage:
+		m, cmd = m.robotsUpdate(msg)
+	case membersPage:
+		m, cmd = m.membersUpdate(msg)
+	case webhooksPage:
+		m, cmd = m.webhooksUpdate(msg)
+	case webhookDeliveriesPage:
+		m, cmd = m.webhookDeliveriesUpdate(msg)
+	case webhookFormPage:
+		m, cmd = m.webhookFormUpdate(msg)
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "ctrl+c", "q":
+		case "ctrl+c":
 			return m, tea.Quit
+		case "q":
+			if !m.isTyping() {
+				return m, tea.Quit
+			}
 		}
 	}
 
@@ -65,6 +177,9 @@ func (m model) View() string {
 	items := []string{}
 	items = append(items, header)
 	items = append(items, content)
+	if secret := m.robotSecretView(); secret != "" {
+		items = append(items, secret)
+	}
 	items = append(items, footer)
 
 	child := lipgloss.JoinVertical(
@@ -92,11 +207,209 @@ func NewModel() (tea.Model, error) {
 		},
 	}
 
-	m.state.projects = m.NewProjectsState()
+	m.state.projects, m.init = m.NewProjectsState()
 
 	return m, nil
 }
 
+// isTyping reports whether the current page is waiting for text input, so
+// global shortcuts must not be triggered.
+func (m model) isTyping() bool {
+	switch m.page {
+	case projectsPage:
+		return m.state.projects.prompt.active()
+	case repositoriesPage:
+		return m.state.repositories.prompt.active()
+	case artifactsPage:
+		return m.state.artifacts.prompt.active()
+	case accessoriesPage:
+		return m.state.accessories.prompt.active()
+	case sbomPage:
+		return m.state.sbom.prompt.active()
+	case searchPage:
+		return m.state.search.prompt.active()
+	case auditPage:
+		return m.state.audit.prompt.active()
+	case robotsPage:
+		return m.state.robots.prompt.active()
+	case membersPage:
+		return m.state.members.prompt.active()
+	case webhooksPage:
+		return m.state.webhooks.prompt.active()
+	case replicationPage:
+		return m.state.replication.prompt.active()
+	case replicationExecutionsPage:
+		return m.state.replicationExecutions.prompt.active()
+	case projectFormPage, webhookFormPage:
+		// Every key belongs to the form
+		return true
+	}
+	return false
+}
+
+func (m model) isReplicationPage() bool {
+	return m.page == replicationPage || m.page == replicationExecutionsPage || m.page == replicationTasksPage
+}
+
+// leavePage cancels the requests in flight on the current page before a
+// global shortcut replaces it, their results would otherwise reach the new
+// page. The page keeps a new context for when the user comes back to it.
+func (m model) leavePage() model {
+	switch m.page {
+	case projectsPage:
+		m.state.projects.cancel()
+		m.state.projects.ctx, m.state.projects.cancel = newPageContext()
+	case repositoriesPage:
+		m.state.repositories.cancel()
+		m.state.repositories.ctx, m.state.repositories.cancel = newPageContext()
+	case artifactsPage:
+		m.state.artifacts.cancel()
+		m.state.artifacts.ctx, m.state.artifacts.cancel = newPageContext()
+	case vulnerabilitiesPage:
+		m.state.vulnerabilities.cancel()
+		m.state.vulnerabilities.ctx, m.state.vulnerabilities.cancel = newPageContext()
+	case buildHistoryPage:
+		m.state.buildHistory.cancel()
+		m.state.buildHistory.ctx, m.state.buildHistory.cancel = newPageContext()
+	case inspectPage:
+		m.state.inspect.cancel()
+		m.state.inspect.ctx, m.state.inspect.cancel = newPageContext()
+	case accessoriesPage:
+		m.state.accessories.cancel()
+		m.state.accessories.ctx, m.state.accessories.cancel = newPageContext()
+	case sbomPage:
+		m.state.sbom.cancel()
+		m.state.sbom.ctx, m.state.sbom.cancel = newPageContext()
+	case packageSearchPage:
+		m.state.packageSearch.cancel()
+		m.state.packageSearch.ctx, m.state.packageSearch.cancel = newPageContext()
+	case searchPage:
+		m.state.search.cancel()
+		m.state.search.ctx, m.state.search.cancel = newPageContext()
+	case statusPage:
+		m.state.status.cancel()
+		m.state.status.ctx, m.state.status.cancel = newPageContext()
+	case replicationPage:
+		m.state.replication.cancel()
+		m.state.replication.ctx, m.state.replication.cancel = newPageContext()
+	case replicationExecutionsPage:
+		m.state.replicationExecutions.cancel()
+		m.state.replicationExecutions.ctx, m.state.replicationExecutions.cancel = newPageContext()
+	case replicationTasksPage:
+		m.state.replicationTasks.cancel()
+		m.state.replicationTasks.ctx, m.state.replicationTasks.cancel = newPageContext()
+	case auditPage:
+		m.state.audit.cancel()
+		m.state.audit.ctx, m.state.audit.cancel = newPageContext()
+	case robotsPage:
+		m.state.robots.cancel()
+		m.state.robots.ctx, m.state.robots.cancel = newPageContext()
+	case membersPage:
+		m.state.members.cancel()
+		m.state.members.ctx, m.state.members.cancel = newPageContext()
+	case webhooksPage:
+		m.state.webhooks.cancel()
+		m.state.webhooks.ctx, m.state.webhooks.cancel = newPageContext()
+	case webhookDeliveriesPage:
+		m.state.webhookDeliveries.cancel()
+		m.state.webhookDeliveries.ctx, m.state.webhookDeliveries.cancel = newPageContext()
+	}
+	return m
+}
+
+// returnTo goes back to a page left through a global shortcut. As its
+// requests were cancelled, a page that had not finished loading is loaded
+// again while the others show what they had.
+func (m model) returnTo(p page) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	m = m.SwitchPage(p)
+
+	switch p {
+	case projectsPage:
+		if len(m.state.projects.data) == 0 {
+			return m.reloadProjects()
+		}
+	case repositoriesPage:
+		if len(m.state.repositories.data) == 0 {
+			state := m.state.repositories
+			m.state.repositories = newEmptyRepositoriesState(state, "Loading...")
+			return m, fetchRepositories(state.ctx, state.project, harbor.QueryOptions{Name: state.filter})
+		}
+	case artifactsPage:
+		if len(m.state.artifacts.data) == 0 {
+			m.state.artifacts = newEmptyArtifactsState(m.state.artifacts, "Loading...")
+			return m, tea.Batch(m.state.artifacts.fetch(), fetchLabels(m.state.artifacts.ctx, m.state.artifacts.project))
+		}
+		return m.backToArtifacts()
+	case vulnerabilitiesPage:
+		if len(m.state.vulnerabilities.data) == 0 {
+			m.state.vulnerabilities, cmd = m.NewVulnerabilitiesState(m.state.vulnerabilities.artifact)
+		}
+	case buildHistoryPage:
+		if len(m.state.buildHistory.data) == 0 {
+			m.state.buildHistory, cmd = m.NewBuildHistoryState(m.state.buildHistory.artifact)
+		}
+	case inspectPage:
+		// The containers running the artifact are looked up again
+		m.state.inspect, cmd = m.NewInspectState(m.state.inspect.artifact, m.state.inspect.platforms)
+	case accessoriesPage:
+		if len(m.state.accessories.data) == 0 {
+			m.state.accessories, cmd = m.NewAccessoriesState(m.state.accessories.artifact)
+		}
+	case sbomPage:
+		if len(m.state.sbom.data) == 0 {
+			m.state.sbom, cmd = m.NewSbomState(m.state.sbom.artifact)
+		}
+	case packageSearchPage:
+		// The repositories searched are not kept, the search must be run again
+		if len(m.state.packageSearch.data) == 0 {
+			m.state.packageSearch = newEmptyPackageSearchState(m.state.packageSearch, "Search cancelled")
+		}
+	case searchPage:
+		if len(m.state.search.data) == 0 && m.state.search.query != "" {
+			m.state.search = newEmptySearchState(m.state.search, "Searching...")
+			cmd = search(m.state.search.ctx, m.state.search.query)
+		}
+	case statusPage:
+		if m.state.status.loading {
+			cmd = fetchStatus(m.state.status.ctx)
+		}
+	case replicationPage:
+		if len(m.state.replication.data) == 0 {
+			m.state.replication = newEmptyReplicationState(m.state.replication, "Loading...")
+			cmd = fetchReplication(m.state.replication.ctx)
+		}
+	case replicationExecutionsPage:
+		// Executions and tasks are followed live, they are always reloaded
+		m.state.replicationExecutions, cmd = m.NewReplicationExecutionsState(m.state.replicationExecutions.policy)
+	case replicationTasksPage:
+		m.state.replicationTasks, cmd = m.NewReplicationTasksState(m.state.replicationTasks.execution)
+	case auditPage:
+		if len(m.state.audit.data) == 0 {
+			return m.reloadAudit()
+		}
+	case robotsPage:
+		if len(m.state.robots.data) == 0 {
+			return m.reloadRobots()
+		}
+	case membersPage:
+		if len(m.state.members.data) == 0 {
+			m.state.members, cmd = m.NewMembersState(m.state.members.project)
+		}
+	case webhooksPage:
+		if len(m.state.webhooks.data) == 0 {
+			return m.reloadWebhooks()
+		}
+	case webhookDeliveriesPage:
+		if len(m.state.webhookDeliveries.data) == 0 {
+			state := m.state.webhookDeliveries
+			m.state.webhookDeliveries, cmd = m.NewWebhookDeliveriesState(state.project, state.policy)
+		}
+	}
+
+	return m, cmd
+}
+
 func (m model) SwitchPage(page page) model {
 	m.page = page
 	return m
@@ -113,8 +426,42 @@ func (m model) getContent() string {
 		page = m.repositoriesView()
 	case artifactsPage:
 		page = m.artifactsView()
+	case vulnerabilitiesPage:
+		page = m.vulnerabilitiesView()
+	case buildHistoryPage:
+		page = m.buildHistoryView()
+	case inspectPage:
+		page = m.inspectView()
+	case accessoriesPage:
+		page = m.accessoriesView()
+	case sbomPage:
+		page = m.sbomView()
+	case packageSearchPage:
+		page = m.packageSearchView()
+	case searchPage:
+		page = m.searchView()
 	case statusPage:
 		page = m.statusView()
+	case projectFormPage:
+		page = m.projectFormView()
+	case replicationPage:
+		page = m.replicationView()
+	case replicationExecutionsPage:
+		page = m.replicationExecutionsView()
+	case replicationTasksPage:
+		page = m.replicationTasksView()
+	case auditPage:
+		page = m.auditView()
+	case robotsPage:
+		page = m.robotsView()
+	case membersPage:
+		page = m.membersView()
+	case webhooksPage:
+		page = m.webhooksView()
+	case webhookDeliveriesPage:
+		page = m.webhookDeliveriesView()
+	case webhookFormPage:
+		page = m.webhookFormView()
 	}
 	return page
 }
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+type Package struct {
+	Name     string
+	Version  string
+	Licenses []string
+}
+
+func (p Package) ToRow() []string {
+	return []string{
+		p.Name,
+		p.Version,
+		strings.Join(p.Licenses, ", "),
+	}
+}
+
+type SbomState struct {
+	table    table.Model
+	data     []Package
+	format   string
+	artifact Artifact
+	filter   string
+	prompt   PromptState
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+type sbomLoadedMsg struct {
+	format   string
+	packages []Package
+	err      error
+}
+
+func sbomAccessory(artifact Artifact) (harbor.Accessory, bool) {
+	for _, a := range artifact.Accessories {
+		if a.IsSbom() {
+			return a, true
+		}
+	}
+	return harbor.Accessory{}, false
+}
+
+func newPackages(sbom *harbor.Sbom) []Package {
+	packages := make([]Package, len(sbom.Packages))
+	for i, p := range sbom.Packages {
+		packages[i] = Package{
+			Name:     p.Name,
+			Version:  p.Version,
+			Licenses: p.Licenses,
+		}
+	}
+	return packages
+}
+
+func fetchSbom(ctx context.Context, artifact Artifact) tea.Cmd {
+	return func() tea.Msg {
+		accessory, ok := sbomAccessory(artifact)
+		if !ok {
+			return sbomLoadedMsg{err: fmt.Errorf("%s has no SBOM", artifact.Name)}
+		}
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			slog.Error("Error creating harbor client", "err", err)
+			return sbomLoadedMsg{err: err}
+		}
+
+		sbom, err := harborClient.FetchSbom(ctx, artifact.Project, artifact.Repository, accessory)
+		if err != nil {
+			slog.Error("Error fetching sbom", "err", err)
+			return sbomLoadedMsg{err: fmt.Errorf("failed to fetch SBOM: %w", err)}
+		}
+
+		return sbomLoadedMsg{format: sbom.Format, packages: newPackages(sbom)}
+	}
+}
+
+// filterPackages keeps the packages whose name contains filter.
+func filterPackages(packages []Package, filter string) []Package {
+	filtered := []Package{}
+	for _, p := range packages {
+		if strings.Contains(strings.ToLower(p.Name), strings.ToLower(filter)) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+func (m model) sbomView() string {
+	state := m.state.sbom
+	title := fmt.Sprintf("SBOM of %s (%s) %s", state.artifact.Name, state.artifact.Hash, state.format)
+	if state.filter != "" {
+		title = fmt.Sprintf("%s, packages matching %q", title, state.filter)
+	}
+
+	return withPrompt(lipgloss.JoinVertical(lipgloss.Left, title, state.table.View()), state.prompt)
+}
+
+func (m model) sbomUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.state.sbom.prompt.handles(msg) {
+		action := m.state.sbom.prompt.action
+		prompt, value, submitted, cmd := m.state.sbom.prompt.update(msg)
+		m.state.sbom.prompt = prompt
+		if submitted && action == filterPromptAction {
+			m.state.sbom.filter = value
+			m.state.sbom.table.SetRows(packageRows(filterPackages(m.state.sbom.data, value)))
+			m.state.sbom.table.GotoTop()
+		}
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case sbomLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.sbom = newEmptySbomState(m.state.sbom, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		m.state.sbom.format = msg.format
+		m.state.sbom = newSbomState(m.state.sbom, msg.packages)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "/":
+			m.state.sbom.prompt, cmd = newPrompt("Filter packages", m.state.sbom.filter, filterPromptAction)
+			return m, cmd
+		case "esc", "-":
+			// Go back
+			m.state.sbom.cancel()
+			return m.backToArtifacts()
+		}
+	}
+
+	m.state.sbom.table, cmd = m.state.sbom.table.Update(msg)
+	return m, cmd
+}
+
+var PACKAGES_COLUMNS = []table.Column{
+	{Title: "Package", Width: 50},
+	{Title: "Version", Width: 30},
+	{Title: "Licenses", Width: 40},
+}
+
+func packageRows(packages []Package) []table.Row {
+	rows := make([]table.Row, len(packages))
+	for i, p := range packages {
+		rows[i] = p.ToRow()
+	}
+	return rows
+}
+
+// newEmptySbomState keeps the request context of state and shows message
+// instead of data.
+func newEmptySbomState(state SbomState, message string) SbomState {
+	t := table.New(
+		table.WithColumns(PACKAGES_COLUMNS),
+		table.WithRows([]table.Row{{message, "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []Package{}
+
+	return state
+}
+
+func newSbomState(state SbomState, packages []Package) SbomState {
+	t := table.New(
+		table.WithColumns(PACKAGES_COLUMNS),
+		table.WithRows(packageRows(packages)),
+		table.WithFocused(true),
+		table.WithHeight(38),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = packages
+
+	slog.Debug("New Sbom state created.")
+
+	return state
+}
+
+// NewSbomState returns a loading SBOM state for artifact along with the
+// command that fetches and parses its SBOM.
+func (m model) NewSbomState(artifact Artifact) (SbomState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := SbomState{
+		artifact: artifact,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	return newEmptySbomState(state, "Loading..."), fetchSbom(ctx, artifact)
+}
@@ -0,0 +1,197 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+const scanPollInterval = 3 * time.Second
+
+type artifactScanMsg struct {
+	artifact Artifact
+	stop     bool
+	err      error
+}
+
+type scanPollMsg struct {
+	id int
+}
+
+type scanStatusMsg struct {
+	hash string
+	scan *harbor.ScanOverview
+	err  error
+}
+
+type projectScanMsg struct {
+	project string
+	scanned int
+	err     error
+}
+
+// scanArtifact starts, or stops when stop is true, the vulnerability scan of
+// artifact.
+func scanArtifact(ctx context.Context, artifact Artifact, stop bool) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return artifactScanMsg{artifact: artifact, stop: stop, err: err}
+		}
+
+		if stop {
+			err = harborClient.StopScanArtifact(ctx, artifact.Project, artifact.Repository, artifact.Hash)
+		} else {
+			err = harborClient.ScanArtifact(ctx, artifact.Project, artifact.Repository, artifact.Hash)
+		}
+
+		return artifactScanMsg{artifact: artifact, stop: stop, err: err}
+	}
+}
+
+func fetchScanStatus(ctx context.Context, artifact Artifact) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return scanStatusMsg{hash: artifact.Hash, err: err}
+		}
+
+		scan, err := harborClient.FetchScanOverview(ctx, artifact.Project, artifact.Repository, artifact.Hash)
+		return scanStatusMsg{hash: artifact.Hash, scan: scan, err: err}
+	}
+}
+
+// scanProject requests a scan of every artifact of every repository in the
+// project, whatever the repositories page currently shows. Failures do not
+// stop the remaining scans and are reported together once all of them were
+// requested.
+func scanProject(ctx context.Context, project string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return projectScanMsg{project: project, err: err}
+		}
+
+		repositories, err := harborClient.FetchRepositories(ctx, project, harbor.QueryOptions{})
+		if err != nil {
+			return projectScanMsg{project: project, err: fmt.Errorf("failed to fetch repositories: %w", err)}
+		}
+
+		scanned := 0
+		errs := []error{}
+		for _, r := range *repositories {
+			name := escapedRepositoryName(r.Name)
+			artifacts, err := harborClient.FetchArtifacts(ctx, project, name, harbor.QueryOptions{})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", r.Name, err))
+				continue
+			}
+
+			for _, a := range *artifacts {
+				if err := harborClient.ScanArtifact(ctx, project, name, a.Digest); err != nil {
+					errs = append(errs, fmt.Errorf("%s@%s: %w", r.Name, a.Digest, err))
+					continue
+				}
+				scanned++
+			}
+
+			if ctx.Err() != nil {
+				break
+			}
+		}
+
+		slog.Debug(fmt.Sprintf("Requested scan of %d artifacts in project %s", scanned, project))
+
+		return projectScanMsg{project: project, scanned: scanned, err: errors.Join(errs...)}
+	}
+}
+
+// pollScans starts a new polling loop following the artifacts still being
+// scanned. Ticks from any previous loop are ignored from now on.
+func (s ArtifactsState) pollScans() (ArtifactsState, tea.Cmd) {
+	s.pollId++
+	id := s.pollId
+	return s, tea.Tick(scanPollInterval, func(time.Time) tea.Msg {
+		return scanPollMsg{id}
+	})
+}
+
+// scansInProgress returns the artifacts whose scan has not finished yet.
+func (s ArtifactsState) scansInProgress() []Artifact {
+	pending := []Artifact{}
+	for _, a := range s.data {
+		if a.Scan != nil && a.Scan.InProgress() {
+			pending = append(pending, a)
+		}
+	}
+	return pending
+}
+
+func (m model) scanUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case artifactScanMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			slog.Error("Error scanning artifact", "err", msg.err)
+			m = m.setError(fmt.Errorf("failed to scan %s: %w", msg.artifact.Name, msg.err))
+			return m, nil
+		}
+
+		if msg.stop {
+			m = m.setInfo(fmt.Sprintf("Stopped scan of %s", msg.artifact.Name))
+			return m, fetchScanStatus(m.state.artifacts.ctx, msg.artifact)
+		}
+
+		for i, a := range m.state.artifacts.data {
+			if a.Hash == msg.artifact.Hash {
+				m.state.artifacts.data[i].Scan = &harbor.ScanOverview{ScanStatus: harbor.ScanStatusPending}
+			}
+		}
+		m.state.artifacts.table.SetRows(artifactRows(m.state.artifacts.data))
+		m = m.setInfo(fmt.Sprintf("Scanning %s", msg.artifact.Name))
+		m.state.artifacts, cmd = m.state.artifacts.pollScans()
+		return m, cmd
+	case scanPollMsg:
+		if msg.id != m.state.artifacts.pollId {
+			return m, nil
+		}
+
+		pending := m.state.artifacts.scansInProgress()
+		if len(pending) == 0 {
+			return m, nil
+		}
+
+		cmds := []tea.Cmd{}
+		for _, a := range pending {
+			cmds = append(cmds, fetchScanStatus(m.state.artifacts.ctx, a))
+		}
+		m.state.artifacts, cmd = m.state.artifacts.pollScans()
+		cmds = append(cmds, cmd)
+
+		return m, tea.Batch(cmds...)
+	case scanStatusMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				slog.Error("Error fetching scan status", "err", msg.err)
+			}
+			return m, nil
+		}
+
+		for i, a := range m.state.artifacts.data {
+			if a.Hash == msg.hash {
+				m.state.artifacts.data[i].Scan = msg.scan
+			}
+		}
+		m.state.artifacts.table.SetRows(artifactRows(m.state.artifacts.data))
+	}
+
+	return m, nil
+}
@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestScansInProgress(t *testing.T) {
+	state := ArtifactsState{data: []Artifact{
+		{Hash: "sha256:never"},
+		{Hash: "sha256:running", Scan: &harbor.ScanOverview{ScanStatus: harbor.ScanStatusRunning}},
+		{Hash: "sha256:done", Scan: &harbor.ScanOverview{ScanStatus: harbor.ScanStatusSuccess}},
+		{Hash: "sha256:pending", Scan: &harbor.ScanOverview{ScanStatus: harbor.ScanStatusPending}},
+	}}
+
+	got := state.scansInProgress()
+	if len(got) != 2 || got[0].Hash != "sha256:running" || got[1].Hash != "sha256:pending" {
+		t.Errorf("scansInProgress() = %+v, want the running and pending artifacts", got)
+	}
+}
+
+func TestPollScansReplacesPreviousLoop(t *testing.T) {
+	state, _ := ArtifactsState{}.pollScans()
+	first := state.pollId
+	state, _ = state.pollScans()
+	if state.pollId == first {
+		t.Errorf("pollScans() kept poll id %d, ticks of the previous loop would not be ignored", first)
+	}
+}
@@ -0,0 +1,275 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+const (
+	searchResultProject    = "project"
+	searchResultRepository = "repository"
+	searchResultTag        = "tag"
+)
+
+type SearchResult struct {
+	Kind    string
+	Project string
+	// Repository is double encoded, as expected by the artifacts page
+	Repository string
+	Tag        string
+	Details    string
+}
+
+func (r SearchResult) ToRow() []string {
+	name := r.Project
+	if r.Repository != "" {
+		name = fmt.Sprintf("%s/%s", r.Project, displayName(r.Repository))
+	}
+	if r.Tag != "" {
+		name = fmt.Sprintf("%s:%s", name, r.Tag)
+	}
+
+	return []string{
+		r.Kind,
+		name,
+		r.Details,
+	}
+}
+
+type SearchState struct {
+	table  table.Model
+	data   []SearchResult
+	query  string
+	prompt PromptState
+	from   page
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type searchMsg struct {
+	results []SearchResult
+	err     error
+}
+
+// search finds projects and repositories by name. A query of the form
+// repository:tag looks for matching tags inside the repositories found.
+func search(ctx context.Context, query string) tea.Cmd {
+	return func() tea.Msg {
+		name, tag, searchTags := strings.Cut(query, ":")
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return searchMsg{err: err}
+		}
+
+		r, err := harborClient.Search(ctx, name)
+		if err != nil {
+			slog.Error("Error searching", "err", err)
+			return searchMsg{err: fmt.Errorf("search failed: %w", err)}
+		}
+
+		results := []SearchResult{}
+		if !searchTags {
+			for _, p := range r.Project {
+				results = append(results, SearchResult{
+					Kind:    searchResultProject,
+					Project: p.Name,
+					Details: fmt.Sprintf("%d repositories", p.RepoCount),
+				})
+			}
+		}
+
+		for _, repo := range r.Repository {
+			repository := escapedRepositoryName(repo.RepositoryName)
+			if !searchTags {
+				results = append(results, SearchResult{
+					Kind:       searchResultRepository,
+					Project:    repo.ProjectName,
+					Repository: repository,
+					Details:    fmt.Sprintf("%d artifacts", repo.ArtifactCount),
+				})
+				continue
+			}
+
+			artifacts, err := harborClient.FetchArtifacts(ctx, repo.ProjectName, repository, harbor.QueryOptions{Tag: tag})
+			if err != nil {
+				return searchMsg{err: fmt.Errorf("search failed: %w", err)}
+			}
+
+			for _, a := range *artifacts {
+				for _, t := range a.Tags {
+					if strings.Contains(t.Name, tag) {
+						results = append(results, SearchResult{
+							Kind:       searchResultTag,
+							Project:    repo.ProjectName,
+							Repository: repository,
+							Tag:        t.Name,
+							Details:    "pushed " + t.PushTime,
+						})
+					}
+				}
+			}
+		}
+
+		return searchMsg{results: results}
+	}
+}
+
+func (m model) searchView() string {
+	state := m.state.search
+	title := "Search projects and repositories, use repository:tag to search tags"
+	if state.query != "" {
+		title = fmt.Sprintf("Results for %q", state.query)
+	}
+
+	return withPrompt(lipgloss.JoinVertical(lipgloss.Left, title, state.table.View()), state.prompt)
+}
+
+func (m model) searchUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.state.search.prompt.handles(msg) {
+		action := m.state.search.prompt.action
+		prompt, value, submitted, cmd := m.state.search.prompt.update(msg)
+		m.state.search.prompt = prompt
+		if submitted && action == searchPromptAction && value != "" {
+			m.state.search.query = value
+			m.state.search = newEmptySearchState(m.state.search, "Searching...")
+			return m, search(m.state.search.ctx, value)
+		}
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case searchMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.search = newEmptySearchState(m.state.search, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		m.state.search = newSearchState(m.state.search, msg.results)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "/":
+			m.state.search.prompt, cmd = newPrompt("Search", m.state.search.query, searchPromptAction)
+			return m, cmd
+		case "enter":
+			if len(m.state.search.data) == 0 {
+				return m, nil
+			}
+			m.state.search.cancel()
+			return m.openSearchResult(m.state.search.data[m.state.search.table.Cursor()])
+		case "esc", "-":
+			// Go back
+			m.state.search.cancel()
+			return m.returnTo(m.state.search.from)
+		}
+	}
+
+	m.state.search.table, cmd = m.state.search.table.Update(msg)
+	return m, cmd
+}
+
+// openSearchResult jumps to the repositories of a project, or to the
+// artifacts of a repository filtered by tag for the other results.
+func (m model) openSearchResult(result SearchResult) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	// The pages left behind may still hold a cancelled context
+	if m.state.repositories.cancel != nil {
+		m.state.repositories.cancel()
+	}
+	if m.state.artifacts.cancel != nil {
+		m.state.artifacts.cancel()
+	}
+	// Going back from the artifacts loads the repositories of their project
+	m.state.repositories = RepositoriesState{}
+
+	if result.Kind == searchResultProject {
+		m.state.repositories, cmd = m.NewRepositoriesState(result.Project)
+		m = m.SwitchPage(repositoriesPage)
+		return m, cmd
+	}
+
+	m.state.artifacts, cmd = m.NewArtifactsState(result.Project, result.Repository, result.Tag)
+	m = m.SwitchPage(artifactsPage)
+	return m, cmd
+}
+
+var SEARCH_COLUMNS = []table.Column{
+	{Title: "Type", Width: 10},
+	{Title: "Name", Width: 70},
+	{Title: "Details", Width: 40},
+}
+
+// newEmptySearchState keeps the request context of state and shows message
+// instead of data.
+func newEmptySearchState(state SearchState, message string) SearchState {
+	t := table.New(
+		table.WithColumns(SEARCH_COLUMNS),
+		table.WithRows([]table.Row{{"", message, ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []SearchResult{}
+
+	return state
+}
+
+func newSearchState(state SearchState, results []SearchResult) SearchState {
+	if len(results) == 0 {
+		return newEmptySearchState(state, "Nothing found")
+	}
+
+	rows := make([]table.Row, len(results))
+	for i, r := range results {
+		rows[i] = r.ToRow()
+	}
+
+	t := table.New(
+		table.WithColumns(SEARCH_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(21),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = results
+
+	slog.Debug("New Search state created.")
+
+	return state
+}
+
+// NewSearchState returns a search state waiting for a query. from is the
+// page to return to.
+func (m model) NewSearchState(from page) (SearchState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := newEmptySearchState(SearchState{
+		from:   from,
+		ctx:    ctx,
+		cancel: cancel,
+	}, "")
+
+	var cmd tea.Cmd
+	state.prompt, cmd = newPrompt("Search", "", searchPromptAction)
+
+	return state, cmd
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v2.0/search":
+			if got := r.URL.Query().Get("q"); got != "app" {
+				t.Errorf("searched %q, want app", got)
+			}
+			w.Write([]byte(`{
+				"project": [{"name": "apps", "repo_count": 2}],
+				"repository": [{"project_name": "library", "repository_name": "library/team/app", "artifact_count": 3}]
+			}`))
+		case "/api/v2.0/projects/library/repositories/team%252Fapp/artifacts":
+			w.Write([]byte(`[{"digest": "sha256:a", "tags": [{"name": "v1.2", "push_time": "2024-01-01"}, {"name": "latest"}]}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("HARBOR_BASEURL", server.URL)
+	t.Setenv("LDAP_USERNAME", "user")
+	t.Setenv("LDAP_PASSWORD", "password")
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "app", want: []string{"project apps", "repository library/team/app"}},
+		{query: "app:v1", want: []string{"tag library/team/app:v1.2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			msg := search(context.Background(), tt.query)().(searchMsg)
+			if msg.err != nil {
+				t.Fatalf("search(%q) error = %v", tt.query, msg.err)
+			}
+			got := []string{}
+			for _, r := range msg.results {
+				row := r.ToRow()
+				got = append(got, row[0]+" "+row[1])
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenSearchResult(t *testing.T) {
+	tests := []struct {
+		result         SearchResult
+		wantPage       page
+		wantProject    string
+		wantRepository string
+		wantFilter     string
+	}{
+		{
+			result:      SearchResult{Kind: searchResultProject, Project: "apps"},
+			wantPage:    repositoriesPage,
+			wantProject: "apps",
+		},
+		{
+			result:         SearchResult{Kind: searchResultRepository, Project: "library", Repository: "team%252Fapp"},
+			wantPage:       artifactsPage,
+			wantProject:    "library",
+			wantRepository: "team%252Fapp",
+		},
+		{
+			result:         SearchResult{Kind: searchResultTag, Project: "library", Repository: "app", Tag: "v1.2"},
+			wantPage:       artifactsPage,
+			wantProject:    "library",
+			wantRepository: "app",
+			wantFilter:     "v1.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.result.Kind, func(t *testing.T) {
+			m, _ := model{}.openSearchResult(tt.result)
+			if m.page != tt.wantPage {
+				t.Fatalf("opened page %v, want %v", m.page, tt.wantPage)
+			}
+			if tt.wantPage == repositoriesPage {
+				if m.state.repositories.project != tt.wantProject {
+					t.Errorf("opened repositories of %q, want %q", m.state.repositories.project, tt.wantProject)
+				}
+				return
+			}
+			a := m.state.artifacts
+			if a.project != tt.wantProject || a.repository != tt.wantRepository || a.filter != tt.wantFilter {
+				t.Errorf("opened artifacts of %s/%s filtered by %q, want %s/%s filtered by %q",
+					a.project, a.repository, a.filter, tt.wantProject, tt.wantRepository, tt.wantFilter)
+			}
+		})
+	}
+}
@@ -1,14 +1,206 @@
 package tui
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+	"github.com/mathiasdonoso/harborw/internal/api/portainer"
+)
+
+var (
+	healthyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	unhealthyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+)
 
 type StatusState struct {
+	loading    bool
+	health     *harbor.HealthResult
+	info       *harbor.SystemInfoResult
+	statistics *harbor.StatisticsResult
+	volumes    *harbor.SystemVolumesResult
+	portainer  *portainer.StatusResult
+	// errs holds why each section could not be loaded, by section title
+	errs   map[string]error
+	from   page
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type statusLoadedMsg struct {
+	health     *harbor.HealthResult
+	info       *harbor.SystemInfoResult
+	statistics *harbor.StatisticsResult
+	volumes    *harbor.SystemVolumesResult
+	portainer  *portainer.StatusResult
+	errs       map[string]error
+}
+
+// fetchStatus loads every section of the status page. A failing section
+// does not prevent the others from being shown.
+func fetchStatus(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		msg := statusLoadedMsg{errs: map[string]error{}}
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			// None of the harbor sections can be loaded
+			for _, title := range []string{"Harbor", "Components", "Statistics", "Storage"} {
+				msg.errs[title] = err
+			}
+		} else {
+			if msg.health, err = harborClient.FetchHealth(ctx); err != nil {
+				msg.errs["Components"] = err
+			}
+			if msg.info, err = harborClient.FetchSystemInfo(ctx); err != nil {
+				msg.errs["Harbor"] = err
+			}
+			if msg.statistics, err = harborClient.FetchStatistics(ctx); err != nil {
+				msg.errs["Statistics"] = err
+			}
+			if msg.volumes, err = harborClient.FetchSystemVolumes(ctx); err != nil {
+				msg.errs["Storage"] = err
+			}
+		}
+
+		portainerClient, err := portainer.NewPortainerApiClient(probeClient)
+		if err == nil {
+			msg.portainer, err = portainerClient.GetStatus(ctx)
+		}
+		if err != nil {
+			msg.errs["Portainer"] = err
+		}
+
+		return msg
+	}
+}
+
+func healthStatus(status string) string {
+	if status == "healthy" {
+		return healthyStyle.Render(status)
+	}
+	return unhealthyStyle.Render(status)
+}
+
+func formatBytes(bytes int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	size := float64(bytes)
+	unit := 0
+	for size >= 1024 && unit < len(units)-1 {
+		size /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%.2f %s", size, units[unit])
+}
+
+// statusSection renders title with lines, or with the error that prevented
+// loading it.
+func (s StatusState) statusSection(title string, lines func() []string) string {
+	if err, ok := s.errs[title]; ok {
+		return section(title, []string{unhealthyStyle.Render(err.Error())})
+	}
+	return section(title, lines())
 }
 
 func (m model) statusView() string {
-	return ""
+	s := m.state.status
+	if s.loading {
+		return "Loading..."
+	}
+
+	sections := []string{
+		s.statusSection("Harbor", func() []string {
+			return []string{
+				fmt.Sprintf("Version: %s", s.info.HarborVersion),
+				fmt.Sprintf("External url: %s", s.info.ExternalUrl),
+				fmt.Sprintf("Auth mode: %s", s.info.AuthMode),
+				fmt.Sprintf("Storage provider: %s", s.info.RegistryStorageProviderName),
+				fmt.Sprintf("Read only: %t", s.info.ReadOnly),
+			}
+		}),
+		s.statusSection("Components", func() []string {
+			lines := []string{fmt.Sprintf("%-12s %s", "overall", healthStatus(s.health.Status))}
+			for _, c := range s.health.Components {
+				line := fmt.Sprintf("%-12s %s", c.Name, healthStatus(c.Status))
+				if c.Error != "" {
+					line = fmt.Sprintf("%s %s", line, c.Error)
+				}
+				lines = append(lines, line)
+			}
+			return lines
+		}),
+		s.statusSection("Statistics", func() []string {
+			return []string{
+				fmt.Sprintf("Projects: %d (%d public, %d private)", s.statistics.TotalProjectCount, s.statistics.PublicProjectCount, s.statistics.PrivateProjectCount),
+				fmt.Sprintf("Repositories: %d (%d public, %d private)", s.statistics.TotalRepoCount, s.statistics.PublicRepoCount, s.statistics.PrivateRepoCount),
+				fmt.Sprintf("Storage used: %s", formatBytes(s.statistics.TotalStorageConsumption)),
+			}
+		}),
+		s.statusSection("Storage", func() []string {
+			lines := []string{}
+			for _, v := range s.volumes.Storage {
+				lines = append(lines, fmt.Sprintf("%s free of %s", formatBytes(v.Free), formatBytes(v.Total)))
+			}
+			return lines
+		}),
+		s.statusSection("Portainer", func() []string {
+			return []string{
+				fmt.Sprintf("Reachable: %s", healthStatus("healthy")),
+				fmt.Sprintf("Version: %s", s.portainer.Version),
+			}
+		}),
+	}
+
+	return strings.Join(sections, "\n")
 }
 
 func (m model) statusUpdate(msg tea.Msg) (model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case statusLoadedMsg:
+		for _, err := range msg.errs {
+			if errors.Is(err, context.Canceled) {
+				return m, nil
+			}
+		}
+
+		m.state.status.loading = false
+		m.state.status.health = msg.health
+		m.state.status.info = msg.info
+		m.state.status.statistics = msg.statistics
+		m.state.status.volumes = msg.volumes
+		m.state.status.portainer = msg.portainer
+		m.state.status.errs = msg.errs
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			// Refresh
+			m.state.status.loading = true
+			return m, fetchStatus(m.state.status.ctx)
+		case "esc", "-":
+			// Go back
+			m.state.status.cancel()
+			return m.returnTo(m.state.status.from)
+		}
+	}
+
 	return m, nil
 }
+
+// NewStatusState returns a loading status state along with the command that
+// fetches it. from is the page to return to.
+func (m model) NewStatusState(from page) (StatusState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := StatusState{
+		loading: true,
+		from:    from,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	return state, fetchStatus(ctx)
+}
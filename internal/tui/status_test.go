@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+	"github.com/mathiasdonoso/harborw/internal/api/portainer"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{bytes: 0, want: "0.00 B"},
+		{bytes: 1023, want: "1023.00 B"},
+		{bytes: 1536, want: "1.50 KiB"},
+		{bytes: 5 << 30, want: "5.00 GiB"},
+		{bytes: 2048 << 40, want: "2048.00 TiB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.bytes); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+func TestStatusViewFailingSections(t *testing.T) {
+	m := model{}
+	m.state.status = StatusState{
+		health:     &harbor.HealthResult{Status: "healthy"},
+		info:       &harbor.SystemInfoResult{HarborVersion: "v2.11.0"},
+		statistics: &harbor.StatisticsResult{},
+		volumes:    &harbor.SystemVolumesResult{},
+		portainer:  &portainer.StatusResult{Version: "2.19.4"},
+		errs:       map[string]error{"Portainer": errors.New("connection refused")},
+	}
+
+	view := ansi.Strip(m.statusView())
+	if !strings.Contains(view, "v2.11.0") {
+		t.Error("harbor section missing while only portainer failed")
+	}
+	if !strings.Contains(view, "connection refused") {
+		t.Error("portainer failure not shown")
+	}
+	if strings.Contains(view, "2.19.4") {
+		t.Error("failed portainer section still shows a version")
+	}
+}
@@ -19,3 +19,8 @@ func GetTableDefaultStyles() table.Styles {
 
 	return s
 }
+
+var (
+	errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	infoStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+)
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+type tagMsg struct {
+	action   promptAction
+	tag      string
+	artifact Artifact
+	err      error
+}
+
+// updateTag adds, removes or moves tag on artifact depending on action.
+func updateTag(ctx context.Context, action promptAction, artifact Artifact, tag string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return tagMsg{action, tag, artifact, err}
+		}
+
+		switch action {
+		case addTagPromptAction:
+			err = harborClient.CreateTag(ctx, artifact.Project, artifact.Repository, artifact.Hash, tag)
+		case deleteTagPromptAction:
+			err = harborClient.DeleteTag(ctx, artifact.Project, artifact.Repository, artifact.Hash, tag)
+		case moveTagPromptAction:
+			err = harborClient.MoveTag(ctx, artifact.Project, artifact.Repository, artifact.Hash, tag)
+		}
+
+		return tagMsg{action, tag, artifact, err}
+	}
+}
+
+func (m model) tagUpdate(msg tagMsg) (model, tea.Cmd) {
+	verb := map[promptAction]string{
+		addTagPromptAction:    "add",
+		deleteTagPromptAction: "remove",
+		moveTagPromptAction:   "move",
+	}[msg.action]
+
+	if msg.err != nil {
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		slog.Error("Error updating tag", "err", msg.err)
+		m = m.setError(fmt.Errorf("failed to %s tag %s: %w", verb, msg.tag, msg.err))
+		return m, nil
+	}
+
+	short := msg.artifact.Hash
+	if len(short) > 19 {
+		short = short[:19]
+	}
+
+	switch msg.action {
+	case addTagPromptAction:
+		m = m.setInfo(fmt.Sprintf("Tagged %s as %s", short, msg.tag))
+	case deleteTagPromptAction:
+		m = m.setInfo(fmt.Sprintf("Removed tag %s from %s", msg.tag, short))
+	case moveTagPromptAction:
+		m = m.setInfo(fmt.Sprintf("Moved tag %s to %s", msg.tag, short))
+	}
+
+	// Tags changed on the server, show them as they are now
+	return m, m.state.artifacts.fetch()
+}
@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+var SEVERITIES = []string{
+	harbor.SeverityCritical,
+	harbor.SeverityHigh,
+	harbor.SeverityMedium,
+	harbor.SeverityLow,
+	harbor.SeverityUnknown,
+	harbor.SeverityNone,
+}
+
+// vulnerabilitiesSummary renders the critical/high/medium/low counts of a
+// scan overview, or the scan status when no report is available.
+func vulnerabilitiesSummary(scan *harbor.ScanOverview) string {
+	if scan == nil {
+		return "Not scanned"
+	}
+
+	if scan.ScanStatus != "Success" {
+		return scan.ScanStatus
+	}
+
+	s := scan.Summary.Summary
+	return fmt.Sprintf(
+		"%d/%d/%d/%d",
+		s[harbor.SeverityCritical],
+		s[harbor.SeverityHigh],
+		s[harbor.SeverityMedium],
+		s[harbor.SeverityLow],
+	)
+}
+
+type Vulnerability struct {
+	Id          string
+	Severity    string
+	Package     string
+	Version     string
+	FixVersion  string
+	Description string
+}
+
+func (v Vulnerability) ToRow() []string {
+	return []string{
+		v.Severity,
+		v.Id,
+		v.Package,
+		v.Version,
+		v.FixVersion,
+	}
+}
+
+type VulnerabilitiesState struct {
+	table    table.Model
+	data     []Vulnerability
+	artifact Artifact
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+type vulnerabilitiesLoadedMsg struct {
+	vulnerabilities []Vulnerability
+	err             error
+}
+
+func fetchVulnerabilities(ctx context.Context, artifact Artifact) tea.Cmd {
+	return func() tea.Msg {
+		if artifact.Additions.Vulnerabilities.Href == "" {
+			return vulnerabilitiesLoadedMsg{err: fmt.Errorf("%s has no vulnerability report", artifact.Name)}
+		}
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			slog.Error("Error creating harbor client", "err", err)
+			return vulnerabilitiesLoadedMsg{err: err}
+		}
+
+		report, err := harborClient.FetchVulnerabilities(ctx, artifact.Additions.Vulnerabilities)
+		if err != nil {
+			slog.Error("Error fetching vulnerabilities", "err", err)
+			return vulnerabilitiesLoadedMsg{err: fmt.Errorf("failed to fetch vulnerabilities: %w", err)}
+		}
+
+		vulnerabilities := make([]Vulnerability, len(report.Vulnerabilities))
+		for i, v := range report.Vulnerabilities {
+			vulnerabilities[i] = Vulnerability{
+				Id:          v.Id,
+				Severity:    v.Severity,
+				Package:     v.Package,
+				Version:     v.Version,
+				FixVersion:  v.FixVersion,
+				Description: v.Description,
+			}
+		}
+
+		// Most severe first
+		slices.SortStableFunc(vulnerabilities, func(a, b Vulnerability) int {
+			return severityRank(a.Severity) - severityRank(b.Severity)
+		})
+
+		return vulnerabilitiesLoadedMsg{vulnerabilities: vulnerabilities}
+	}
+}
+
+func severityRank(severity string) int {
+	rank := slices.Index(SEVERITIES, severity)
+	if rank < 0 {
+		return len(SEVERITIES)
+	}
+	return rank
+}
+
+func (m model) vulnerabilitiesView() string {
+	state := m.state.vulnerabilities
+	title := fmt.Sprintf("Vulnerabilities of %s (%s)", state.artifact.Name, state.artifact.Hash)
+
+	items := []string{title, state.table.View()}
+	if len(state.data) > 0 {
+		active := state.data[state.table.Cursor()]
+		items = append(items, infoStyle.Render(active.Description))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, items...)
+}
+
+func (m model) vulnerabilitiesUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case vulnerabilitiesLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.vulnerabilities = newEmptyVulnerabilitiesState(m.state.vulnerabilities, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		m.state.vulnerabilities = newVulnerabilitiesState(m.state.vulnerabilities, msg.vulnerabilities)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			m.state.vulnerabilities.cancel()
+			return m.backToArtifacts()
+		}
+	}
+
+	m.state.vulnerabilities.table, cmd = m.state.vulnerabilities.table.Update(msg)
+	return m, cmd
+}
+
+var VULNERABILITIES_COLUMNS = []table.Column{
+	{Title: "Severity", Width: 10},
+	{Title: "CVE", Width: 20},
+	{Title: "Package", Width: 30},
+	{Title: "Version", Width: 20},
+	{Title: "Fixed in", Width: 20},
+}
+
+// newEmptyVulnerabilitiesState keeps the request context of state and shows
+// message instead of data.
+func newEmptyVulnerabilitiesState(state VulnerabilitiesState, message string) VulnerabilitiesState {
+	t := table.New(
+		table.WithColumns(VULNERABILITIES_COLUMNS),
+		table.WithRows([]table.Row{{"", message, "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []Vulnerability{}
+
+	return state
+}
+
+func newVulnerabilitiesState(state VulnerabilitiesState, vulnerabilities []Vulnerability) VulnerabilitiesState {
+	if len(vulnerabilities) == 0 {
+		return newEmptyVulnerabilitiesState(state, "No vulnerabilities found")
+	}
+
+	rows := make([]table.Row, len(vulnerabilities))
+	for i, v := range vulnerabilities {
+		rows[i] = v.ToRow()
+	}
+
+	t := table.New(
+		table.WithColumns(VULNERABILITIES_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(38),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = vulnerabilities
+
+	slog.Debug("New Vulnerabilities state created.")
+
+	return state
+}
+
+// NewVulnerabilitiesState returns a loading vulnerabilities state for
+// artifact along with the command that fetches its report.
+func (m model) NewVulnerabilitiesState(artifact Artifact) (VulnerabilitiesState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := VulnerabilitiesState{
+		artifact: artifact,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	return newEmptyVulnerabilitiesState(state, "Loading..."), fetchVulnerabilities(ctx, artifact)
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestVulnerabilitiesSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		scan *harbor.ScanOverview
+		want string
+	}{
+		{name: "not scanned", scan: nil, want: "Not scanned"},
+		{name: "running", scan: &harbor.ScanOverview{ScanStatus: "Running"}, want: "Running"},
+		{
+			name: "success",
+			scan: &harbor.ScanOverview{ScanStatus: "Success", Summary: harbor.VulnerabilitySummary{Summary: map[string]int{
+				harbor.SeverityCritical: 1,
+				harbor.SeverityHigh:     2,
+				harbor.SeverityLow:      4,
+				harbor.SeverityUnknown:  8,
+			}}},
+			want: "1/2/0/4",
+		},
+		{name: "success without findings", scan: &harbor.ScanOverview{ScanStatus: "Success"}, want: "0/0/0/0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vulnerabilitiesSummary(tt.scan); got != tt.want {
+				t.Errorf("vulnerabilitiesSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverityRank(t *testing.T) {
+	order := []string{harbor.SeverityCritical, harbor.SeverityHigh, harbor.SeverityMedium, harbor.SeverityLow, "Bogus"}
+	for i := 1; i < len(order); i++ {
+		if severityRank(order[i-1]) >= severityRank(order[i]) {
+			t.Errorf("severityRank(%s) is not before severityRank(%s)", order[i-1], order[i])
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+// webhookField identifies a field of the webhook form. Fields past
+// firstEventField are the event types, in the order of the form.
+type webhookField int
+
+const (
+	webhookNameField webhookField = iota
+	webhookTypeField
+	webhookAddressField
+	webhookAuthField
+	webhookSkipCertField
+	webhookEnabledField
+	firstEventField
+)
+
+var webhookTargetTypes = []string{harbor.WebhookTargetHttp, harbor.WebhookTargetSlack}
+
+type WebhookFormState struct {
+	project Project
+	// policy is the webhook being edited, its id is 0 for a new one
+	policy     harbor.WebhookPolicyResult
+	loading    bool
+	saving     bool
+	name       textinput.Model
+	address    textinput.Model
+	auth       textinput.Model
+	targetType int
+	skipCert   bool
+	enabled    bool
+	eventTypes []string
+	events     map[string]bool
+	focus      webhookField
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+type webhookEventTypesMsg struct {
+	eventTypes []string
+	err        error
+}
+
+func fetchWebhookEventTypes(ctx context.Context, project string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return webhookEventTypesMsg{err: err}
+		}
+
+		events, err := harborClient.FetchWebhookEventTypes(ctx, project)
+		if err != nil {
+			return webhookEventTypesMsg{err: fmt.Errorf("failed to fetch webhook event types: %w", err)}
+		}
+
+		return webhookEventTypesMsg{eventTypes: events.EventType}
+	}
+}
+
+// saveWebhook creates the webhook of the form, or replaces it when it
+// already exists.
+func saveWebhook(ctx context.Context, project string, policy harbor.WebhookPolicyResult) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return webhookUpdatedMsg{err: err}
+		}
+
+		if policy.Id == 0 {
+			err = harborClient.CreateWebhookPolicy(ctx, project, policy)
+		} else {
+			err = harborClient.UpdateWebhookPolicy(ctx, project, policy)
+		}
+		if err != nil {
+			slog.Error("Error saving webhook policy", "err", err)
+			return webhookUpdatedMsg{err: fmt.Errorf("failed to save webhook %s: %w", policy.Name, err)}
+		}
+
+		return webhookUpdatedMsg{message: fmt.Sprintf("Webhook %s saved", policy.Name)}
+	}
+}
+
+// webhookPolicy returns the policy described by the form, keeping what the
+// form does not edit from the original policy.
+func (s WebhookFormState) webhookPolicy() harbor.WebhookPolicyResult {
+	policy := s.policy
+	policy.Name = strings.TrimSpace(s.name.Value())
+	policy.Enabled = s.enabled
+
+	target := harbor.WebhookTarget{}
+	if len(policy.Targets) > 0 {
+		target = policy.Targets[0]
+	}
+	target.Type = webhookTargetTypes[s.targetType]
+	target.Address = strings.TrimSpace(s.address.Value())
+	target.AuthHeader = s.auth.Value()
+	target.SkipCertVerify = s.skipCert
+	policy.Targets = append([]harbor.WebhookTarget{target}, policy.Targets[min(1, len(policy.Targets)):]...)
+
+	policy.EventTypes = []string{}
+	for _, e := range s.eventTypes {
+		if s.events[e] {
+			policy.EventTypes = append(policy.EventTypes, e)
+		}
+	}
+
+	return policy
+}
+
+func (s WebhookFormState) fieldCount() int {
+	return int(firstEventField) + len(s.eventTypes)
+}
+
+func (s WebhookFormState) setFocus(field webhookField) (WebhookFormState, tea.Cmd) {
+	s.focus = field
+	s.name.Blur()
+	s.address.Blur()
+	s.auth.Blur()
+
+	switch field {
+	case webhookNameField:
+		return s, s.name.Focus()
+	case webhookAddressField:
+		return s, s.address.Focus()
+	case webhookAuthField:
+		return s, s.auth.Focus()
+	}
+	return s, nil
+}
+
+func (s WebhookFormState) moveFocus(offset int) (WebhookFormState, tea.Cmd) {
+	n := s.fieldCount()
+	return s.setFocus(webhookField((int(s.focus) + offset + n) % n))
+}
+
+// toggle flips the focused checkbox or cycles the target type.
+func (s WebhookFormState) toggle() WebhookFormState {
+	switch {
+	case s.focus == webhookTypeField:
+		s.targetType = (s.targetType + 1) % len(webhookTargetTypes)
+	case s.focus == webhookSkipCertField:
+		s.skipCert = !s.skipCert
+	case s.focus == webhookEnabledField:
+		s.enabled = !s.enabled
+	case s.focus >= firstEventField:
+		e := s.eventTypes[s.focus-firstEventField]
+		s.events[e] = !s.events[e]
+	}
+	return s
+}
+
+func (s WebhookFormState) isTextField() bool {
+	return s.focus == webhookNameField || s.focus == webhookAddressField || s.focus == webhookAuthField
+}
+
+func (m model) webhookFormView() string {
+	s := m.state.webhookForm
+	if s.loading {
+		return "Loading..."
+	}
+
+	title := fmt.Sprintf("New webhook for %s", s.project.Name)
+	if s.policy.Id != 0 {
+		title = fmt.Sprintf("Webhook %s", s.policy.Name)
+	}
+
+	lines := []string{
+		formLine(s.focus == webhookNameField, "Name", s.name.View()),
+		formLine(s.focus == webhookTypeField, "Notify type", fmt.Sprintf("< %s >", webhookTargetTypes[s.targetType])),
+		formLine(s.focus == webhookAddressField, "Endpoint", s.address.View()),
+		formLine(s.focus == webhookAuthField, "Auth header", s.auth.View()),
+		formLine(s.focus == webhookSkipCertField, "Skip certificate verification", checkbox(s.skipCert)),
+		formLine(s.focus == webhookEnabledField, "Enabled", checkbox(s.enabled)),
+		"",
+		"Events",
+	}
+	for i, e := range s.eventTypes {
+		lines = append(lines, formLine(s.focus == firstEventField+webhookField(i), e, checkbox(s.events[e])))
+	}
+
+	help := "tab/↑/↓ move, space change, enter save, esc cancel"
+	if s.saving {
+		help = "Saving..."
+	}
+	lines = append(lines, "", infoStyle.Render(help))
+
+	return section(title, lines)
+}
+
+func (m model) webhookFormUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	s := m.state.webhookForm
+
+	switch msg := msg.(type) {
+	case webhookEventTypesMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m = m.setError(msg.err)
+			m = m.SwitchPage(webhooksPage)
+			return m, nil
+		}
+
+		s.loading = false
+		s.eventTypes = msg.eventTypes
+		// Keep events of the policy the server did not list
+		for _, e := range s.policy.EventTypes {
+			if !slices.Contains(s.eventTypes, e) {
+				s.eventTypes = append(s.eventTypes, e)
+			}
+		}
+		m.state.webhookForm = s
+		return m, nil
+	case webhookUpdatedMsg:
+		s.saving = false
+		m.state.webhookForm = s
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		s.cancel()
+		m = m.setInfo(msg.message)
+		return m.reloadWebhooks()
+	case tea.KeyMsg:
+		if s.loading || s.saving {
+			if msg.String() == "esc" {
+				s.cancel()
+				m = m.SwitchPage(webhooksPage)
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "esc":
+			s.cancel()
+			m = m.SwitchPage(webhooksPage)
+			return m, nil
+		case "tab", "down":
+			m.state.webhookForm, cmd = s.moveFocus(1)
+			return m, cmd
+		case "shift+tab", "up":
+			m.state.webhookForm, cmd = s.moveFocus(-1)
+			return m, cmd
+		case "enter":
+			policy := s.webhookPolicy()
+			if policy.Name == "" || policy.Targets[0].Address == "" {
+				m = m.setError(errors.New("the webhook needs a name and an endpoint"))
+				return m, nil
+			}
+			if len(policy.EventTypes) == 0 {
+				m = m.setError(errors.New("the webhook needs at least one event"))
+				return m, nil
+			}
+			s.saving = true
+			m.state.webhookForm = s
+			return m, saveWebhook(s.ctx, s.project.Name, policy)
+		case " ", "left", "right":
+			if !s.isTextField() {
+				m.state.webhookForm = s.toggle()
+				return m, nil
+			}
+		}
+	}
+
+	switch s.focus {
+	case webhookNameField:
+		s.name, cmd = s.name.Update(msg)
+	case webhookAddressField:
+		s.address, cmd = s.address.Update(msg)
+	case webhookAuthField:
+		s.auth, cmd = s.auth.Update(msg)
+	}
+	m.state.webhookForm = s
+
+	return m, cmd
+}
+
+// NewWebhookFormState returns the form editing policy, or creating a new
+// webhook in project when policy is nil, along with the command that loads
+// the event types it can be notified of.
+func (m model) NewWebhookFormState(project Project, policy *harbor.WebhookPolicyResult) (WebhookFormState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+
+	newInput := func(placeholder string) textinput.Model {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = placeholder
+		return input
+	}
+
+	state := WebhookFormState{
+		project: project,
+		loading: true,
+		name:    newInput("webhook name"),
+		address: newInput("https://example.com/hook"),
+		auth:    newInput("Bearer token"),
+		enabled: true,
+		events:  map[string]bool{},
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	// The header usually holds a token
+	state.auth.EchoMode = textinput.EchoPassword
+
+	if policy != nil {
+		state.policy = *policy
+		state.name.SetValue(policy.Name)
+		state.enabled = policy.Enabled
+		if len(policy.Targets) > 0 {
+			target := policy.Targets[0]
+			state.targetType = max(slices.Index(webhookTargetTypes, target.Type), 0)
+			state.address.SetValue(target.Address)
+			state.auth.SetValue(target.AuthHeader)
+			state.skipCert = target.SkipCertVerify
+		}
+		for _, e := range policy.EventTypes {
+			state.events[e] = true
+		}
+	}
+
+	state, cmd := state.setFocus(webhookNameField)
+	return state, tea.Batch(cmd, fetchWebhookEventTypes(ctx, project.Name))
+}
@@ -0,0 +1,625 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+// webhookDeliveriesLimit is how many of the latest deliveries are inspected.
+const webhookDeliveriesLimit = 15
+
+// statusCodePattern finds the http status code answered by a webhook target
+// in the status message, or the log, of a delivery attempt. Harbor only
+// records that code when the target answered with an error, successful
+// deliveries show no code.
+var statusCodePattern = regexp.MustCompile(`(?i)(?:status code|response code)(?: is)?[:= ]+(\d{3})`)
+
+func webhookPolicyRow(p harbor.WebhookPolicyResult) []string {
+	enabled := "no"
+	if p.Enabled {
+		enabled = "yes"
+	}
+
+	targets := make([]string, len(p.Targets))
+	for i, t := range p.Targets {
+		targets[i] = fmt.Sprintf("%s %s", t.Type, t.Address)
+	}
+
+	return []string{
+		p.Name,
+		enabled,
+		strings.Join(p.EventTypes, ", "),
+		strings.Join(targets, ", "),
+	}
+}
+
+// WebhookDelivery is an execution of a webhook policy along with the outcome
+// of its last attempt.
+type WebhookDelivery struct {
+	Execution harbor.WebhookExecutionResult
+	// Task is the last attempt, nil when there is none or until TaskKnown
+	Task      *harbor.WebhookTaskResult
+	TaskKnown bool
+	TaskErr   error
+	// StatusCode is the http status answered by the target, empty when
+	// unknown
+	StatusCode string
+}
+
+// statusCode returns the status code found in text, or an empty string.
+func statusCode(text string) string {
+	if match := statusCodePattern.FindStringSubmatch(text); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+func (d WebhookDelivery) ToRow() []string {
+	attempts, message := "", d.Execution.StatusMessage
+	switch {
+	case d.TaskErr != nil:
+		attempts = "unknown"
+	case !d.TaskKnown:
+		attempts = "..."
+	case d.Task != nil:
+		attempts = strconv.Itoa(d.Task.RunCount)
+		if d.Task.StatusMessage != "" {
+			message = d.Task.StatusMessage
+		}
+	}
+
+	code := d.StatusCode
+	if code == "" {
+		code = "-"
+	}
+
+	return []string{
+		strconv.Itoa(d.Execution.Id),
+		d.Execution.EventType(),
+		d.Execution.Status,
+		code,
+		attempts,
+		d.Execution.StartTime,
+		message,
+	}
+}
+
+type WebhooksState struct {
+	table   table.Model
+	data    []harbor.WebhookPolicyResult
+	project Project
+	// sysadmin is set when the current user administers harbor itself
+	sysadmin bool
+	prompt   PromptState
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+type WebhookDeliveriesState struct {
+	table   table.Model
+	data    []WebhookDelivery
+	project Project
+	policy  harbor.WebhookPolicyResult
+	log     string
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+type webhooksLoadedMsg struct {
+	policies []harbor.WebhookPolicyResult
+	sysadmin bool
+	err      error
+}
+
+type webhookUpdatedMsg struct {
+	message string
+	err     error
+}
+
+type webhookDeliveriesLoadedMsg struct {
+	deliveries []WebhookDelivery
+	err        error
+}
+
+type webhookTaskMsg struct {
+	executionId int
+	task        *harbor.WebhookTaskResult
+	err         error
+}
+
+type webhookLogMsg struct {
+	delivery WebhookDelivery
+	log      string
+	err      error
+}
+
+func fetchWebhooks(ctx context.Context, project string) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return webhooksLoadedMsg{err: err}
+		}
+
+		r, err := harborClient.FetchWebhookPolicies(ctx, project)
+		if err != nil {
+			slog.Error("Error fetching webhook policies", "err", err)
+			return webhooksLoadedMsg{err: fmt.Errorf("failed to fetch webhooks of %s: %w", project, err)}
+		}
+
+		return webhooksLoadedMsg{policies: *r, sysadmin: currentUserIsSysadmin(ctx)}
+	}
+}
+
+// toggleWebhook enables policy, or disables it when enabled.
+func toggleWebhook(ctx context.Context, project string, policy harbor.WebhookPolicyResult) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return webhookUpdatedMsg{err: err}
+		}
+
+		policy.Enabled = !policy.Enabled
+		verb := "disable"
+		if policy.Enabled {
+			verb = "enable"
+		}
+
+		if err := harborClient.UpdateWebhookPolicy(ctx, project, policy); err != nil {
+			slog.Error("Error updating webhook policy", "err", err)
+			return webhookUpdatedMsg{err: fmt.Errorf("failed to %s webhook %s: %w", verb, policy.Name, err)}
+		}
+
+		return webhookUpdatedMsg{message: fmt.Sprintf("Webhook %s %sd", policy.Name, verb)}
+	}
+}
+
+func deleteWebhook(ctx context.Context, project string, policy harbor.WebhookPolicyResult) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return webhookUpdatedMsg{err: err}
+		}
+
+		if err := harborClient.DeleteWebhookPolicy(ctx, project, policy.Id); err != nil {
+			slog.Error("Error deleting webhook policy", "err", err)
+			return webhookUpdatedMsg{err: fmt.Errorf("failed to delete webhook %s: %w", policy.Name, err)}
+		}
+
+		return webhookUpdatedMsg{message: fmt.Sprintf("Webhook %s deleted", policy.Name)}
+	}
+}
+
+// fetchWebhookDeliveries loads the latest executions of policy. Their last
+// attempt is loaded afterwards by fetchWebhookTask, one per execution.
+func fetchWebhookDeliveries(ctx context.Context, project string, policy harbor.WebhookPolicyResult) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return webhookDeliveriesLoadedMsg{err: err}
+		}
+
+		e, err := harborClient.FetchWebhookExecutions(ctx, project, policy.Id, webhookDeliveriesLimit)
+		if err != nil {
+			slog.Error("Error fetching webhook executions", "err", err)
+			return webhookDeliveriesLoadedMsg{err: fmt.Errorf("failed to fetch deliveries of %s: %w", policy.Name, err)}
+		}
+
+		deliveries := make([]WebhookDelivery, len(*e))
+		for i, execution := range *e {
+			deliveries[i] = WebhookDelivery{Execution: execution}
+		}
+
+		return webhookDeliveriesLoadedMsg{deliveries: deliveries}
+	}
+}
+
+// fetchWebhookTask loads the last attempt of the execution identified by
+// executionId, reading the status code from its status message. The log is
+// only fetched when asked for.
+func fetchWebhookTask(ctx context.Context, project string, policy harbor.WebhookPolicyResult, executionId int) tea.Cmd {
+	return func() tea.Msg {
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return webhookTaskMsg{executionId: executionId, err: err}
+		}
+
+		tasks, err := harborClient.FetchWebhookTasks(ctx, project, policy.Id, executionId)
+		if err != nil {
+			slog.Error("Error fetching webhook tasks", "execution", executionId, "err", err)
+			return webhookTaskMsg{executionId: executionId, err: err}
+		}
+
+		msg := webhookTaskMsg{executionId: executionId}
+		if len(*tasks) > 0 {
+			msg.task = &(*tasks)[len(*tasks)-1]
+		}
+		return msg
+	}
+}
+
+// webhookDeliveryRows returns the rows of deliveries.
+func webhookDeliveryRows(deliveries []WebhookDelivery) []table.Row {
+	rows := make([]table.Row, len(deliveries))
+	for i, d := range deliveries {
+		rows[i] = d.ToRow()
+	}
+	return rows
+}
+
+func fetchWebhookLog(ctx context.Context, project string, policy harbor.WebhookPolicyResult, delivery WebhookDelivery) tea.Cmd {
+	return func() tea.Msg {
+		if !delivery.TaskKnown {
+			return webhookLogMsg{delivery: delivery, err: fmt.Errorf("attempts of delivery %d are still loading", delivery.Execution.Id)}
+		}
+		if delivery.Task == nil {
+			return webhookLogMsg{delivery: delivery, err: fmt.Errorf("delivery %d has no attempt", delivery.Execution.Id)}
+		}
+
+		harborClient, err := harbor.NewHarborApiClient(httpClient)
+		if err != nil {
+			return webhookLogMsg{delivery: delivery, err: err}
+		}
+
+		log, err := harborClient.FetchWebhookTaskLog(ctx, project, policy.Id, delivery.Execution.Id, delivery.Task.Id)
+		if err != nil {
+			return webhookLogMsg{delivery: delivery, err: fmt.Errorf("failed to fetch log of delivery %d: %w", delivery.Execution.Id, err)}
+		}
+
+		return webhookLogMsg{delivery: delivery, log: log}
+	}
+}
+
+// editable reports whether the current user may change the webhooks, which
+// requires administering the project, or harbor.
+func (s WebhooksState) editable() bool {
+	return s.sysadmin || s.project.RoleId == harbor.RoleProjectAdmin
+}
+
+func (m model) webhooksView() string {
+	state := m.state.webhooks
+	items := []string{sectionTitleStyle.Render(fmt.Sprintf("Webhooks of %s", state.project.Name)), state.table.View()}
+
+	if len(state.data) > 0 {
+		active := state.data[state.table.Cursor()]
+		for _, t := range active.Targets {
+			auth := "no auth header"
+			if t.AuthHeader != "" {
+				auth = "auth header set"
+			}
+			items = append(items, infoStyle.Render(fmt.Sprintf("%s %s, %s, skip cert verify: %t", t.Type, t.Address, auth, t.SkipCertVerify)))
+		}
+	}
+
+	return withPrompt(lipgloss.JoinVertical(lipgloss.Left, items...), state.prompt)
+}
+
+func (m model) webhookDeliveriesView() string {
+	state := m.state.webhookDeliveries
+	items := []string{sectionTitleStyle.Render(fmt.Sprintf("Last deliveries of %s", state.policy.Name)), state.table.View()}
+	if state.log != "" {
+		items = append(items, infoStyle.Render(state.log))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, items...)
+}
+
+// reloadWebhooks shows the webhooks page and fetches its data again.
+func (m model) reloadWebhooks() (model, tea.Cmd) {
+	state := m.state.webhooks
+	m.state.webhooks = newEmptyWebhooksState(state, "Loading...")
+	m = m.SwitchPage(webhooksPage)
+	return m, fetchWebhooks(state.ctx, state.project.Name)
+}
+
+func (m model) webhooksUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	state := m.state.webhooks
+	if state.prompt.handles(msg) {
+		action := state.prompt.action
+		prompt, value, submitted, cmd := state.prompt.update(msg)
+		m.state.webhooks.prompt = prompt
+		if !submitted || value != "yes" || len(state.data) == 0 {
+			return m, cmd
+		}
+		active := state.data[state.table.Cursor()]
+		switch action {
+		case deleteWebhookPromptAction:
+			return m, deleteWebhook(state.ctx, state.project.Name, active)
+		case toggleWebhookPromptAction:
+			return m, toggleWebhook(state.ctx, state.project.Name, active)
+		}
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case webhooksLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.webhooks = newEmptyWebhooksState(state, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		state.sysadmin = msg.sysadmin
+		m.state.webhooks = newWebhooksState(state, msg.policies)
+		return m, nil
+	case webhookUpdatedMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		m = m.setInfo(msg.message)
+		return m.reloadWebhooks()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			state.cancel()
+			m = m.SwitchPage(projectsPage)
+			return m, nil
+		case "n", "e", "d", "D":
+			if !state.editable() {
+				m = m.setError(fmt.Errorf("only administrators of %s can change its webhooks", state.project.Name))
+				return m, nil
+			}
+		}
+
+		if msg.String() == "n" {
+			// Create a webhook
+			m.state.webhookForm, cmd = m.NewWebhookFormState(state.project, nil)
+			m = m.SwitchPage(webhookFormPage)
+			return m, cmd
+		}
+
+		if len(state.data) == 0 {
+			break
+		}
+		active := state.data[state.table.Cursor()]
+		switch msg.String() {
+		case "e":
+			// Edit the webhook
+			m.state.webhookForm, cmd = m.NewWebhookFormState(state.project, &active)
+			m = m.SwitchPage(webhookFormPage)
+			return m, cmd
+		case "d":
+			// Enable or disable the webhook once confirmed
+			verb := "Enable"
+			if active.Enabled {
+				verb = "Disable"
+			}
+			label := fmt.Sprintf("%s webhook %s? Type yes to confirm", verb, active.Name)
+			m.state.webhooks.prompt, cmd = newPrompt(label, "", toggleWebhookPromptAction)
+			return m, cmd
+		case "D":
+			label := fmt.Sprintf("Delete webhook %s? Type yes to confirm", active.Name)
+			m.state.webhooks.prompt, cmd = newPrompt(label, "", deleteWebhookPromptAction)
+			return m, cmd
+		case "enter":
+			// Inspect the last deliveries
+			m.state.webhookDeliveries, cmd = m.NewWebhookDeliveriesState(state.project, active)
+			m = m.SwitchPage(webhookDeliveriesPage)
+			return m, cmd
+		}
+	}
+
+	m.state.webhooks.table, cmd = state.table.Update(msg)
+	return m, cmd
+}
+
+func (m model) webhookDeliveriesUpdate(msg tea.Msg) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	state := m.state.webhookDeliveries
+
+	switch msg := msg.(type) {
+	case webhookDeliveriesLoadedMsg:
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			m.state.webhookDeliveries = newEmptyWebhookDeliveriesState(state, "No data available")
+			m = m.setError(msg.err)
+			return m, nil
+		}
+
+		m.state.webhookDeliveries = newWebhookDeliveriesState(state, msg.deliveries)
+		cmds := make([]tea.Cmd, len(msg.deliveries))
+		for i, d := range msg.deliveries {
+			cmds[i] = fetchWebhookTask(state.ctx, state.project.Name, state.policy, d.Execution.Id)
+		}
+		return m, tea.Batch(cmds...)
+	case webhookTaskMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+
+		// A failing execution only leaves its own row unknown
+		for i, d := range state.data {
+			if d.Execution.Id == msg.executionId {
+				state.data[i].Task = msg.task
+				state.data[i].TaskKnown = true
+				state.data[i].TaskErr = msg.err
+				if msg.task != nil {
+					state.data[i].StatusCode = statusCode(msg.task.StatusMessage)
+				}
+			}
+		}
+		state.table.SetRows(webhookDeliveryRows(state.data))
+		m.state.webhookDeliveries = state
+		return m, nil
+	case webhookLogMsg:
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m = m.setError(msg.err)
+			}
+			return m, nil
+		}
+
+		// The log may tell the status code the status message did not
+		for i, d := range state.data {
+			if d.Execution.Id == msg.delivery.Execution.Id && d.StatusCode == "" {
+				state.data[i].StatusCode = statusCode(msg.log)
+			}
+		}
+		state.table.SetRows(webhookDeliveryRows(state.data))
+		m.state.webhookDeliveries = state
+		m.state.webhookDeliveries.log = fmt.Sprintf("Log of delivery %d:\n%s", msg.delivery.Execution.Id, lastLines(msg.log, taskLogLines))
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "-":
+			// Go back
+			state.cancel()
+			m = m.SwitchPage(webhooksPage)
+			return m, nil
+		case "u":
+			// Refresh
+			m.state.webhookDeliveries = newEmptyWebhookDeliveriesState(state, "Loading...")
+			return m, fetchWebhookDeliveries(state.ctx, state.project.Name, state.policy)
+		case "l", "enter":
+			// Show the end of the log of the delivery
+			if len(state.data) == 0 {
+				return m, nil
+			}
+			active := state.data[state.table.Cursor()]
+			return m, fetchWebhookLog(state.ctx, state.project.Name, state.policy, active)
+		}
+	}
+
+	m.state.webhookDeliveries.table, cmd = state.table.Update(msg)
+	return m, cmd
+}
+
+var WEBHOOKS_COLUMNS = []table.Column{
+	{Title: "Name", Width: 24},
+	{Title: "Enabled", Width: 8},
+	{Title: "Events", Width: 50},
+	{Title: "Targets", Width: 50},
+}
+
+var WEBHOOK_DELIVERIES_COLUMNS = []table.Column{
+	{Title: "Id", Width: 8},
+	{Title: "Event", Width: 20},
+	{Title: "Status", Width: 10},
+	{Title: "Code", Width: 5},
+	{Title: "Attempts", Width: 8},
+	{Title: "Start time", Width: 26},
+	{Title: "Message", Width: 50},
+}
+
+// newEmptyWebhooksState keeps the request context of state and shows
+// message instead of data.
+func newEmptyWebhooksState(state WebhooksState, message string) WebhooksState {
+	t := table.New(
+		table.WithColumns(WEBHOOKS_COLUMNS),
+		table.WithRows([]table.Row{{message, "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []harbor.WebhookPolicyResult{}
+
+	return state
+}
+
+func newWebhooksState(state WebhooksState, policies []harbor.WebhookPolicyResult) WebhooksState {
+	rows := make([]table.Row, len(policies))
+	for i, p := range policies {
+		rows[i] = webhookPolicyRow(p)
+	}
+
+	t := table.New(
+		table.WithColumns(WEBHOOKS_COLUMNS),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(18),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = policies
+
+	slog.Debug("New Webhooks state created.")
+
+	return state
+}
+
+// newEmptyWebhookDeliveriesState keeps the request context of state and
+// shows message instead of data.
+func newEmptyWebhookDeliveriesState(state WebhookDeliveriesState, message string) WebhookDeliveriesState {
+	t := table.New(
+		table.WithColumns(WEBHOOK_DELIVERIES_COLUMNS),
+		table.WithRows([]table.Row{{message, "", "", "", "", "", ""}}),
+		table.WithFocused(true),
+		table.WithHeight(2),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = []WebhookDelivery{}
+
+	return state
+}
+
+func newWebhookDeliveriesState(state WebhookDeliveriesState, deliveries []WebhookDelivery) WebhookDeliveriesState {
+	t := table.New(
+		table.WithColumns(WEBHOOK_DELIVERIES_COLUMNS),
+		table.WithRows(webhookDeliveryRows(deliveries)),
+		table.WithFocused(true),
+		table.WithHeight(webhookDeliveriesLimit+1),
+	)
+
+	t.SetStyles(GetTableDefaultStyles())
+
+	state.table = t
+	state.data = deliveries
+
+	slog.Debug("New Webhook deliveries state created.")
+
+	return state
+}
+
+// NewWebhooksState returns a loading state with the webhooks of project
+// along with the command that fetches them.
+func (m model) NewWebhooksState(project Project) (WebhooksState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := WebhooksState{
+		project: project,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	return newEmptyWebhooksState(state, "Loading..."), fetchWebhooks(ctx, project.Name)
+}
+
+// NewWebhookDeliveriesState returns a loading state with the last deliveries
+// of policy along with the command that fetches them.
+func (m model) NewWebhookDeliveriesState(project Project, policy harbor.WebhookPolicyResult) (WebhookDeliveriesState, tea.Cmd) {
+	ctx, cancel := newPageContext()
+	state := WebhookDeliveriesState{
+		project: project,
+		policy:  policy,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	return newEmptyWebhookDeliveriesState(state, "Loading..."), fetchWebhookDeliveries(ctx, project.Name, policy)
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mathiasdonoso/harborw/internal/api/harbor"
+)
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "http job error",
+			text: "webhook job(target: https://ci.example.com/hook) response code is 500",
+			want: "500",
+		},
+		{
+			name: "slack job error",
+			text: "slack job(target: https://hooks.slack.com/services/x) response code is 404",
+			want: "404",
+		},
+		{
+			name: "job log line",
+			text: "2024-03-10T12:00:00Z [ERROR] [/jobservice/job/impl/notification/webhook_job.go:91]: webhook job(target: https://ci.example.com) response code is 502\n",
+			want: "502",
+		},
+		{
+			name: "status code with colon",
+			text: "unexpected status code: 401",
+			want: "401",
+		},
+		{
+			name: "upper case",
+			text: "Response Code = 403",
+			want: "403",
+		},
+		{
+			name: "successful delivery logs no code",
+			text: "2024-03-10T12:00:00Z [INFO] [/jobservice/runner/redis.go:120]: Job 'WEBHOOK:abc' exit with success",
+			want: "",
+		},
+		{
+			name: "connection error",
+			text: "Post \"https://ci.example.com/hook\": dial tcp: lookup ci.example.com: no such host",
+			want: "",
+		},
+		{
+			name: "empty",
+			text: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusCode(tt.text); got != tt.want {
+				t.Errorf("statusCode(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookDeliveryRow(t *testing.T) {
+	execution := harbor.WebhookExecutionResult{Id: 7, Status: "Error", StatusMessage: "execution failed"}
+	task := &harbor.WebhookTaskResult{Id: 3, RunCount: 2, StatusMessage: "response code is 500"}
+
+	tests := []struct {
+		name         string
+		delivery     WebhookDelivery
+		wantAttempts string
+		wantCode     string
+		wantMessage  string
+	}{
+		{
+			name:         "attempt loading",
+			delivery:     WebhookDelivery{Execution: execution},
+			wantAttempts: "...",
+			wantCode:     "-",
+			wantMessage:  "execution failed",
+		},
+		{
+			name:         "attempt failed to load",
+			delivery:     WebhookDelivery{Execution: execution, TaskKnown: true, TaskErr: harbor.ErrNotFound},
+			wantAttempts: "unknown",
+			wantCode:     "-",
+			wantMessage:  "execution failed",
+		},
+		{
+			name:         "no attempt",
+			delivery:     WebhookDelivery{Execution: execution, TaskKnown: true},
+			wantAttempts: "",
+			wantCode:     "-",
+			wantMessage:  "execution failed",
+		},
+		{
+			name:         "failed attempt",
+			delivery:     WebhookDelivery{Execution: execution, Task: task, TaskKnown: true, StatusCode: "500"},
+			wantAttempts: "2",
+			wantCode:     "500",
+			wantMessage:  "response code is 500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := tt.delivery.ToRow()
+			if row[3] != tt.wantCode || row[4] != tt.wantAttempts || row[6] != tt.wantMessage {
+				t.Errorf("ToRow() = %q, want code %q, attempts %q and message %q", row, tt.wantCode, tt.wantAttempts, tt.wantMessage)
+			}
+		})
+	}
+}